@@ -0,0 +1,30 @@
+action "test_codebuild_start_build" "deploy" {
+  config {
+    project_name = "my-deploy-project"
+
+    environment_variables {
+      name  = "ENVIRONMENT"
+      value = "production"
+    }
+
+    environment_variables {
+      name  = "API_KEY"
+      value = "/my-app/api-key"
+      type  = "PARAMETER_STORE"
+    }
+
+    timeout = "30m"
+  }
+}
+
+# Start a build every time the released version changes
+resource "terraform_data" "release" {
+  input = "1.4.2"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.test_codebuild_start_build.deploy]
+    }
+  }
+}
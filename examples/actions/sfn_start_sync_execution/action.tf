@@ -0,0 +1,20 @@
+action "test_sfn_start_sync_execution" "warm_up" {
+  config {
+    state_machine_arn = "arn:aws:states:eu-west-1:123456789012:stateMachine:warm-up"
+    input = jsonencode({
+      environment = "production"
+    })
+  }
+}
+
+# Run the state machine once, when the environment is first created
+resource "terraform_data" "environment" {
+  input = "production"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.test_sfn_start_sync_execution.warm_up]
+    }
+  }
+}
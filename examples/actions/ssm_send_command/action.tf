@@ -0,0 +1,28 @@
+action "test_ssm_send_command" "restart_app" {
+  config {
+    document_name = "AWS-RunShellScript"
+    instance_ids  = ["i-1234567890abcdef0"]
+
+    parameters = {
+      "commands" = "systemctl restart app"
+    }
+
+    comment = "Restart the application after a configuration change"
+    timeout = "5m"
+  }
+}
+
+# Invoke the action every time the configuration of the application changes
+resource "terraform_data" "app_config" {
+  input = filesha256("app.conf")
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.test_ssm_send_command.restart_app]
+    }
+  }
+}
+
+# The action can also be invoked on demand:
+# terraform apply -invoke=action.test_ssm_send_command.restart_app
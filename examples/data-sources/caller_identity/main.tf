@@ -0,0 +1,11 @@
+data "test_caller_identity" "current" {}
+
+output "caller_identity" {
+  value = {
+    account_id   = data.test_caller_identity.current.account_id
+    arn          = data.test_caller_identity.current.arn
+    user_id      = data.test_caller_identity.current.user_id
+    role_arn     = data.test_caller_identity.current.role_arn
+    session_name = data.test_caller_identity.current.session_name
+  }
+}
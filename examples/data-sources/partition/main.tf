@@ -0,0 +1,15 @@
+data "test_partition" "current" {}
+
+data "test_caller_identity" "current" {}
+
+resource "test_sfn_start_sync_execution" "example" {
+  state_machine_arn = "arn:${data.test_partition.current.partition}:states:${data.test_partition.current.region}:${data.test_caller_identity.current.account_id}:stateMachine:MyStateMachine"
+}
+
+output "partition" {
+  value = {
+    partition  = data.test_partition.current.partition
+    dns_suffix = data.test_partition.current.dns_suffix
+    region     = data.test_partition.current.region
+  }
+}
@@ -0,0 +1,28 @@
+# Read a JSON secret from Secrets Manager and extract some of its keys
+ephemeral "test_secret_value" "database" {
+  secret_id = "prod/database"
+  json_keys = ["username", "password"]
+}
+
+# Read a SecureString parameter from Parameter Store (decrypted)
+ephemeral "test_secret_value" "api_token" {
+  parameter_name = "/prod/api/token"
+}
+
+# Ephemeral values can be used in provider configurations, provisioners,
+# locals, ephemeral outputs and write-only arguments, and are never stored in the state.
+provider "postgresql" {
+  host     = "db.example.com"
+  username = ephemeral.test_secret_value.database.values["username"]
+  password = ephemeral.test_secret_value.database.values["password"]
+}
+
+resource "terraform_data" "deploy" {
+  provisioner "local-exec" {
+    command = "./deploy.sh"
+
+    environment = {
+      API_TOKEN = ephemeral.test_secret_value.api_token.value
+    }
+  }
+}
@@ -0,0 +1,24 @@
+# Short-lived activation used only to bootstrap a hybrid node during this run
+ephemeral "test_ssm_activation" "bootstrap" {
+  iam_role           = "SSMServiceRole"
+  description        = "Bootstrap of hybrid node"
+  registration_limit = 1
+  expires_in         = "30m"
+
+  tags = {
+    Purpose = "bootstrap"
+  }
+}
+
+# Ephemeral values can be used in provisioners: the activation code
+# is passed to the registration script without being stored in the state.
+resource "terraform_data" "register" {
+  provisioner "local-exec" {
+    command = "ssh admin@hybrid-node sudo amazon-ssm-agent -register -id \"$ACTIVATION_ID\" -code \"$ACTIVATION_CODE\" -region eu-west-1"
+
+    environment = {
+      ACTIVATION_ID   = ephemeral.test_ssm_activation.bootstrap.activation_id
+      ACTIVATION_CODE = ephemeral.test_ssm_activation.bootstrap.activation_code
+    }
+  }
+}
@@ -0,0 +1,13 @@
+data "test_partition" "current" {}
+
+data "test_caller_identity" "current" {}
+
+# IAM role ARN (IAM is a global service: no region)
+output "role_arn" {
+  value = provider::test::arn_build(data.test_partition.current.partition, "iam", null, data.test_caller_identity.current.account_id, "role/my-role")
+}
+
+# Step Functions state machine ARN in the provider region
+output "state_machine_arn" {
+  value = provider::test::arn_build(data.test_partition.current.partition, "states", data.test_partition.current.region, data.test_caller_identity.current.account_id, "stateMachine:my-state-machine")
+}
@@ -0,0 +1,17 @@
+locals {
+  state_machine = provider::test::arn_parse("arn:aws:states:eu-west-1:123456789012:stateMachine:my-state-machine")
+}
+
+# Account ID and region of the state machine, without split()
+output "state_machine_account_id" {
+  value = local.state_machine.account_id
+}
+
+output "state_machine_region" {
+  value = local.state_machine.region
+}
+
+# Resource part of the ARN: "stateMachine:my-state-machine"
+output "state_machine_resource" {
+  value = local.state_machine.resource
+}
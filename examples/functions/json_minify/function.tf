@@ -0,0 +1,15 @@
+# Remove whitespace from a JSON file before passing it as a parameter
+output "minified_file" {
+  value = provider::test::json_minify(file("${path.module}/config.json"))
+}
+
+# Minify an inline JSON document
+output "minified_inline" {
+  value = provider::test::json_minify(<<-JSON
+    {
+      "name": "John",
+      "age": 30
+    }
+  JSON
+  )
+}
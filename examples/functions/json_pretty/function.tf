@@ -0,0 +1,16 @@
+# Format a JSON document with 2 spaces of indentation
+output "pretty_policy" {
+  value = provider::test::json_pretty(jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect   = "Allow"
+      Action   = "ssm:SendCommand"
+      Resource = "*"
+    }]
+  }), 2)
+}
+
+# Re-indent an existing JSON file with 4 spaces
+output "pretty_file" {
+  value = provider::test::json_pretty(file("${path.module}/config.json"), 4)
+}
@@ -0,0 +1,14 @@
+# Plan time in UTC
+output "plan_time_utc" {
+  value = provider::test::timestamp(plantimestamp(), null, null)
+}
+
+# Plan time in Paris, shifted by one day and a half
+output "tomorrow_noon_paris" {
+  value = provider::test::timestamp(plantimestamp(), "Europe/Paris", "1d12h")
+}
+
+# One hour before a fixed date in UTC
+output "one_hour_before" {
+  value = provider::test::timestamp("2024-01-15T10:00:00Z", "UTC", "-1h")
+}
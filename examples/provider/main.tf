@@ -13,11 +13,91 @@ provider "test" {
   profile = "xxxx"
 }
 
+# Example with static credentials and custom shared files
+provider "test" {
+  alias                    = "with_static_credentials"
+  region                   = "eu-west-1"
+  access_key               = "xxxx"
+  secret_key               = "yyyy"
+  shared_config_files      = ["/ci/aws/config"]
+  shared_credentials_files = ["/ci/aws/credentials"]
+}
+
 # Example with assume_role
 provider "test" {
-  alias  = "with_assume_role"
-  region = "eu-west-1"
+  alias               = "with_assume_role"
+  region              = "eu-west-1"
+  allowed_account_ids = ["xxxx"]
   assume_role {
     role_arn = "arn:aws:iam::xxxx:role/yyyy"
   }
 }
+
+# Example with chained assume_role (bastion account, then workload account)
+provider "test" {
+  alias  = "with_role_chaining"
+  region = "eu-west-1"
+  assume_role {
+    role_arn = "arn:aws:iam::xxxx:role/bastion"
+  }
+  assume_role {
+    role_arn        = "arn:aws:iam::yyyy:role/workload"
+    external_id     = "my-external-id"
+    duration        = "1h"
+    source_identity = "ci"
+    tags = {
+      Project = "terraform-provider-test"
+    }
+    transitive_tag_keys = ["Project"]
+  }
+}
+
+# Example with an OIDC token from a CI pipeline
+provider "test" {
+  alias  = "with_web_identity"
+  region = "eu-west-1"
+  assume_role_with_web_identity {
+    role_arn                = "arn:aws:iam::xxxx:role/ci"
+    web_identity_token_file = "/tmp/web-identity-token"
+    session_name            = "pipeline"
+  }
+}
+
+# Example with a local AWS emulator
+provider "test" {
+  alias    = "local"
+  region   = "us-east-1"
+  insecure = true
+  endpoints {
+    ssm            = "http://localhost:4566"
+    sts            = "http://localhost:4566"
+    secretsmanager = "http://localhost:4566"
+  }
+}
+
+# Example for large accounts behind a corporate proxy
+provider "test" {
+  alias            = "large_account"
+  region           = "eu-west-1"
+  max_retries      = 10
+  retry_mode       = "adaptive"
+  request_timeout  = "1m"
+  http_proxy       = "http://proxy.example.com:3128"
+  custom_ca_bundle = "~/certs/corporate-ca.pem"
+  rate_limits = {
+    ssm        = 5
+    cloudwatch = 2
+  }
+}
+
+# Example with tags applied to every taggable resource
+provider "test" {
+  alias  = "with_default_tags"
+  region = "eu-west-1"
+  default_tags {
+    tags = {
+      CostCenter = "platform"
+      Owner      = "ops-team"
+    }
+  }
+}
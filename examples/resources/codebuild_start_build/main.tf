@@ -62,6 +62,12 @@ resource "test_codebuild_start_build" "complete_example" {
   }
 }
 
+# Example waiting for the build to complete
+resource "test_codebuild_start_build" "wait_example" {
+  project_name        = "my-codebuild-project"
+  wait_for_completion = true
+}
+
 # Outputs to retrieve build information
 output "basic_build_id" {
   description = "ID of the basic build"
@@ -8,3 +8,94 @@ resource "test_ssm_send_command" "example" {
   
   comment = "Basic SSM command example"
 }
+
+# Example of a long-running command with a custom timeout
+resource "test_ssm_send_command" "long_running" {
+  document_name = "AWS-RunShellScript"
+  instance_ids  = ["i-1234567890abcdef0"]
+
+  parameters = {
+    "commands" = "yum update -y"
+  }
+
+  timeouts {
+    create = "45m"
+  }
+}
+
+# Example of a command run again whenever the deployed script changes
+resource "test_ssm_send_command" "deploy" {
+  document_name = "AWS-RunShellScript"
+  instance_ids  = ["i-1234567890abcdef0"]
+
+  parameters = {
+    "commands" = file("${path.module}/deploy.sh")
+  }
+
+  triggers_replace = [
+    filesha256("${path.module}/deploy.sh"),
+  ]
+}
+
+# Example of a command whose output is consumed by other resources
+resource "test_ssm_send_command" "hostname" {
+  document_name = "AWS-RunShellScript"
+  instance_ids  = ["i-1234567890abcdef0"]
+
+  parameters = {
+    "commands" = "hostname -f"
+  }
+}
+
+output "hostname" {
+  value     = trimspace(test_ssm_send_command.hostname.invocations[0].standard_output)
+  sensitive = true
+}
+
+# Example of a rolling change across a fleet, with SNS notifications
+resource "test_ssm_send_command" "rolling" {
+  document_name    = "AWS-RunShellScript"
+  document_version = "$DEFAULT"
+
+  targets {
+    key    = "tag:Environment"
+    values = ["production"]
+  }
+
+  parameters = {
+    "commands" = "systemctl restart nginx"
+  }
+
+  max_concurrency = "10%"
+  max_errors      = "1"
+  timeout_seconds = 600
+
+  service_role_arn = "arn:aws:iam::123456789012:role/ssm-notifications"
+
+  notification_config {
+    notification_arn    = "arn:aws:sns:eu-west-1:123456789012:ssm-commands"
+    notification_events = ["Success", "Failed", "TimedOut"]
+    notification_type   = "Command"
+  }
+}
+
+# Example of a command whose complete output is stored in S3 and CloudWatch Logs
+resource "test_ssm_send_command" "inventory" {
+  document_name = "AWS-RunShellScript"
+  instance_ids  = ["i-1234567890abcdef0"]
+
+  parameters = {
+    "commands" = "rpm -qa"
+  }
+
+  output_s3_bucket_name = "my-ssm-output"
+  output_s3_key_prefix  = "inventory"
+
+  cloudwatch_output_config {
+    log_group_name = "/ssm/inventory"
+  }
+}
+
+output "inventory_url" {
+  value = test_ssm_send_command.inventory.invocations[0].standard_output_url
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+	"github.com/jd-ucpa/terraform-provider-test/internal/region"
 )
 
 // Ensure CloudWatchAlarmsDataSource satisfies various datasource interfaces.
@@ -68,7 +70,7 @@ func NewCloudWatchAlarmsDataSource() datasource.DataSource {
 // CloudWatchAlarmsDataSource récupère les alarmes CloudWatch selon les critères spécifiés
 // via l'API CloudWatch DescribeAlarms.
 type CloudWatchAlarmsDataSource struct {
-	cloudwatch *cloudwatch.Client
+	meta *conns.ProviderMeta
 }
 
 // CloudWatchAlarmsDataSourceModel définit le modèle de données pour le data source
@@ -80,6 +82,7 @@ type CloudWatchAlarmsDataSourceModel struct {
 	StateValue              types.String `tfsdk:"state_value"`
 	IgnoreAutoscalingAlarms types.Bool   `tfsdk:"ignore_autoscaling_alarms"`
 	ID                      types.String `tfsdk:"id"`
+	Region                  types.String `tfsdk:"region"`
 	Alarms                  []Alarm      `tfsdk:"alarms"`
 }
 
@@ -117,23 +120,14 @@ func (d *CloudWatchAlarmsDataSource) Metadata(ctx context.Context, req datasourc
 
 // Configure initialise le client CloudWatch à partir de la configuration du provider.
 func (d *CloudWatchAlarmsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	// Éviter le panic si le provider n'a pas été configuré
-	if req.ProviderData == nil {
+	meta, diags := conns.FromProviderData(req.ProviderData, "CloudWatch alarms data source")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
 		return
 	}
 
-	// Vérifier que la configuration est du bon type
-	config, ok := req.ProviderData.(aws.Config)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Provider configuration error",
-			fmt.Sprintf("Expected aws.Config for CloudWatch alarms data source, got: %T. This indicates a provider configuration issue. Please verify your provider configuration and report this issue if it persists.", req.ProviderData),
-		)
-		return
-	}
-	
-	// Créer le client CloudWatch à partir de la configuration AWS
-	d.cloudwatch = cloudwatch.NewFromConfig(config)
+	// Conserver la configuration du provider pour créer les clients de la région demandée
+	d.meta = meta
 }
 
 // Schema définit la structure et la documentation du data source.
@@ -179,6 +173,7 @@ func (d *CloudWatchAlarmsDataSource) Schema(ctx context.Context, req datasource.
 				MarkdownDescription: "The ID of the data source.",
 				Computed:            true,
 			},
+			"region": region.DataSourceAttribute(),
 		},
 		Blocks: map[string]schema.Block{
 			"alarms": schema.ListNestedBlock{
@@ -285,6 +280,9 @@ func (d *CloudWatchAlarmsDataSource) Read(ctx context.Context, req datasource.Re
 		return
 	}
 
+	// Utiliser le client de la région de la configuration
+	conn := d.meta.CloudWatchClient(data.Region.ValueString())
+
 	// Définir les valeurs par défaut
 	if data.AlarmType.IsNull() || data.AlarmType.IsUnknown() {
 		data.AlarmType = types.StringValue("MetricAlarm")
@@ -342,7 +340,7 @@ func (d *CloudWatchAlarmsDataSource) Read(ctx context.Context, req datasource.Re
 		}
 
 		// Appeler l'API CloudWatch
-		result, err := d.cloudwatch.DescribeAlarms(ctx, input)
+		result, err := conn.DescribeAlarms(ctx, input)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to retrieve CloudWatch alarms",
@@ -10,6 +10,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+	"github.com/jd-ucpa/terraform-provider-test/internal/region"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -21,7 +23,7 @@ func NewCloudWatchMetricsDataSource() datasource.DataSource {
 
 // CloudWatchMetricsDataSource defines the data source implementation.
 type CloudWatchMetricsDataSource struct {
-	cloudwatch *cloudwatch.Client
+	meta *conns.ProviderMeta
 }
 
 // CloudWatchMetricsDataSourceModel describes the data source data model.
@@ -31,6 +33,7 @@ type CloudWatchMetricsDataSourceModel struct {
 	Dimensions  []DimensionModel      `tfsdk:"dimension"`
 	Metrics     []MetricModel         `tfsdk:"metrics"`
 	Id          basetypes.StringValue `tfsdk:"id"`
+	Region      basetypes.StringValue `tfsdk:"region"`
 }
 
 // DimensionModel describes the dimension data model.
@@ -68,6 +71,7 @@ func (d *CloudWatchMetricsDataSource) Schema(ctx context.Context, req datasource
 				MarkdownDescription: "The ID of the data source.",
 				Computed:            true,
 			},
+			"region": region.DataSourceAttribute(),
 			"metrics": schema.ListNestedAttribute{
 				MarkdownDescription: "List of CloudWatch metrics matching the criteria.",
 				Computed:            true,
@@ -122,23 +126,14 @@ func (d *CloudWatchMetricsDataSource) Schema(ctx context.Context, req datasource
 }
 
 func (d *CloudWatchMetricsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	// Prevent panic if the provider has not been configured.
-	if req.ProviderData == nil {
+	meta, diags := conns.FromProviderData(req.ProviderData, "CloudWatch metrics data source")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
 		return
 	}
 
-	// Vérifier que la configuration est du bon type
-	config, ok := req.ProviderData.(aws.Config)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Provider configuration error",
-			fmt.Sprintf("Expected aws.Config for CloudWatch metrics data source, got: %T. This indicates a provider configuration issue. Please verify your provider configuration and report this issue if it persists.", req.ProviderData),
-		)
-		return
-	}
-	
-	// Créer le client CloudWatch à partir de la configuration AWS
-	d.cloudwatch = cloudwatch.NewFromConfig(config)
+	// Conserver la configuration du provider pour créer les clients de la région demandée
+	d.meta = meta
 }
 
 func (d *CloudWatchMetricsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -151,6 +146,9 @@ func (d *CloudWatchMetricsDataSource) Read(ctx context.Context, req datasource.R
 		return
 	}
 
+	// Utiliser le client de la région de la configuration
+	conn := d.meta.CloudWatchClient(data.Region.ValueString())
+
 	// Build the input parameters for ListMetrics
 	input := &cloudwatch.ListMetricsInput{}
 
@@ -174,7 +172,7 @@ func (d *CloudWatchMetricsDataSource) Read(ctx context.Context, req datasource.R
 	}
 
 	// Call the AWS API
-	result, err := d.cloudwatch.ListMetrics(ctx, input)
+	result, err := conn.ListMetrics(ctx, input)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to retrieve CloudWatch metrics",
@@ -0,0 +1,140 @@
+package codebuild
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+	"github.com/jd-ucpa/terraform-provider-test/internal/failuremode"
+	"github.com/jd-ucpa/terraform-provider-test/internal/region"
+	"github.com/jd-ucpa/terraform-provider-test/internal/timeouts"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &StartBuildAction{}
+var _ action.ActionWithConfigure = &StartBuildAction{}
+
+// NewStartBuildAction crée et retourne une nouvelle instance de l'action StartBuildAction.
+// Cette fonction est utilisée par le provider pour enregistrer l'action dans Terraform.
+func NewStartBuildAction() action.Action {
+	return &StartBuildAction{}
+}
+
+// StartBuildAction démarre un build CodeBuild et attend sa fin lorsqu'elle est invoquée, sans
+// rien enregistrer dans l'état.
+type StartBuildAction struct {
+	meta *conns.ProviderMeta
+}
+
+// StartBuildActionModel définit le modèle de données pour l'action StartBuild.
+type StartBuildActionModel struct {
+	Region               types.String                       `tfsdk:"region"`
+	ProjectName          types.String                       `tfsdk:"project_name"`
+	EnvironmentVariables []EnvironmentVariableResourceModel `tfsdk:"environment_variables"`
+	Timeout              types.String                       `tfsdk:"timeout"`
+}
+
+// Metadata définit le nom du type d'action utilisé dans les configurations Terraform.
+func (a *StartBuildAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = "test_codebuild_start_build"
+}
+
+// Configure conserve la configuration du provider pour créer le client CodeBuild de la région demandée.
+func (a *StartBuildAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	meta, diags := conns.FromProviderData(req.ProviderData, "CodeBuild start build action")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
+		return
+	}
+
+	// Conserver la configuration du provider pour créer les clients de la région de l'action
+	a.meta = meta
+}
+
+// Schema définit la structure et la documentation de l'action.
+func (a *StartBuildAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `test_codebuild_start_build` action starts a build using AWS CodeBuild and waits for it to complete. The action fails if the build does not succeed. Nothing is stored in the state. Requires Terraform 1.14 or later.",
+		Attributes: map[string]schema.Attribute{
+			"region": region.ActionAttribute(),
+			"project_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the CodeBuild project to start a build for.",
+				Required:            true,
+			},
+			"timeout": timeouts.ActionAttribute(startBuildTimeouts.Create),
+		},
+		Blocks: map[string]schema.Block{
+			"environment_variables": schema.ListNestedBlock{
+				MarkdownDescription: "The environment variables to pass to the build.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the environment variable.",
+							Required:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The value of the environment variable.",
+							Required:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the environment variable. Valid values are PLAINTEXT, PARAMETER_STORE, or SECRETS_MANAGER. Defaults to PLAINTEXT.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Invoke démarre le build et attend sa fin. Le démarrage et l'attente sont ceux de la
+// ressource test_codebuild_start_build, avec failure_mode = "error".
+func (a *StartBuildAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config StartBuildActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Construire le modèle de la ressource à partir de la configuration de l'action
+	data := StartBuildResourceModel{
+		Region:               config.Region,
+		ProjectName:          config.ProjectName,
+		EnvironmentVariables: config.EnvironmentVariables,
+		FailureMode:          types.StringValue(failuremode.Error),
+	}
+	r := &StartBuildResource{meta: a.meta}
+
+	// Récupérer le délai d'attente de la fin du build
+	timeout, diag := timeouts.Action(config.Timeout, startBuildTimeouts.Create)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	// Utiliser le client CodeBuild de la région de l'action
+	conn := a.meta.CodeBuildClient(data.Region.ValueString())
+
+	// Démarrer le build
+	resp.Diagnostics.Append(r.startBuild(ctx, conn, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("CodeBuild build '%s' started, waiting for it to complete", data.BuildId.ValueString()),
+	})
+
+	// Attendre la fin du build
+	resp.Diagnostics.Append(r.waitForBuild(ctx, conn, &data, timeout)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("CodeBuild build '%s' completed with status '%s'", data.BuildId.ValueString(), data.BuildStatus.ValueString()),
+	})
+}
@@ -3,6 +3,8 @@ package codebuild
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/codebuild"
@@ -13,9 +15,27 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+	"github.com/jd-ucpa/terraform-provider-test/internal/failuremode"
+	"github.com/jd-ucpa/terraform-provider-test/internal/region"
+	"github.com/jd-ucpa/terraform-provider-test/internal/timeouts"
+	"github.com/jd-ucpa/terraform-provider-test/internal/triggers"
+	"github.com/jd-ucpa/terraform-provider-test/internal/waiter"
 )
 
+// startBuildTimeouts définit le délai d'attente par défaut de la fin d'un build.
+// Il correspond au délai d'exécution par défaut d'un projet CodeBuild (60 minutes).
+// Seule la création lance un build.
+var startBuildTimeouts = timeouts.Defaults{
+	Create: 60 * time.Minute,
+}
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &StartBuildResource{}
 
@@ -29,9 +49,7 @@ func NewStartBuildResource() resource.Resource {
 // StartBuildResource gère le démarrage de builds AWS CodeBuild.
 // Cette ressource permet de démarrer un build CodeBuild et de surveiller son statut.
 type StartBuildResource struct {
-	codebuild *codebuild.Client
-	ssm       *ssm.Client
-	secrets   *secretsmanager.Client
+	meta *conns.ProviderMeta
 }
 
 // EnvironmentVariableResourceModel définit le modèle pour les variables d'environnement.
@@ -44,17 +62,23 @@ type EnvironmentVariableResourceModel struct {
 // StartBuildResourceModel définit le modèle de données pour la ressource StartBuild.
 // Il contient tous les attributs de configuration et les données retournées par l'API CodeBuild.
 type StartBuildResourceModel struct {
-	Id                   types.String                        `tfsdk:"id"`
-	ProjectName          types.String                        `tfsdk:"project_name"`
-	EnvironmentVariables []EnvironmentVariableResourceModel  `tfsdk:"environment_variables"`
-	Triggers             types.Map                           `tfsdk:"triggers"`
+	Id                   types.String                       `tfsdk:"id"`
+	Region               types.String                       `tfsdk:"region"`
+	ProjectName          types.String                       `tfsdk:"project_name"`
+	EnvironmentVariables []EnvironmentVariableResourceModel `tfsdk:"environment_variables"`
+	Triggers             types.Map                          `tfsdk:"triggers"`
+	TriggersReplace      types.Dynamic                      `tfsdk:"triggers_replace"`
+	WaitForCompletion    types.Bool                         `tfsdk:"wait_for_completion"`
+	FailureMode          types.String                       `tfsdk:"failure_mode"`
+	Timeouts             types.Object                       `tfsdk:"timeouts"`
 	// Propriétés retournées directement (sans imbrication dans "build")
-	BuildId              types.String                        `tfsdk:"build_id"`
-	BuildArn             types.String                        `tfsdk:"build_arn"`
-	BuildNumber          types.Int64                         `tfsdk:"build_number"`
-	BuildProjectName     types.String                        `tfsdk:"build_project_name"`
-	BuildImage           types.String                        `tfsdk:"build_image"`
-	BuildEnvironmentVariables types.List `tfsdk:"build_environment_variables"`
+	BuildId                   types.String `tfsdk:"build_id"`
+	BuildArn                  types.String `tfsdk:"build_arn"`
+	BuildNumber               types.Int64  `tfsdk:"build_number"`
+	BuildProjectName          types.String `tfsdk:"build_project_name"`
+	BuildStatus               types.String `tfsdk:"build_status"`
+	BuildImage                types.String `tfsdk:"build_image"`
+	BuildEnvironmentVariables types.List   `tfsdk:"build_environment_variables"`
 }
 
 // Metadata définit le nom du type de ressource utilisé dans les configurations Terraform.
@@ -67,25 +91,14 @@ func (r *StartBuildResource) Metadata(ctx context.Context, req resource.Metadata
 // Cette méthode est appelée par Terraform pour configurer la ressource avec
 // les paramètres d'authentification AWS (région, credentials, etc.).
 func (r *StartBuildResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	// Éviter le panic si le provider n'a pas été configuré
-	if req.ProviderData == nil {
+	meta, diags := conns.FromProviderData(req.ProviderData, "CodeBuild start build resource")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
 		return
 	}
 
-	// Vérifier que la configuration est du bon type
-	config, ok := req.ProviderData.(aws.Config)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Provider configuration error",
-			fmt.Sprintf("Expected aws.Config for CodeBuild start build resource, got: %T. This indicates a provider configuration issue. Please verify your provider configuration and report this issue if it persists.", req.ProviderData),
-		)
-		return
-	}
-	
-	// Créer les clients AWS à partir de la configuration
-	r.codebuild = codebuild.NewFromConfig(config)
-	r.ssm = ssm.NewFromConfig(config)
-	r.secrets = secretsmanager.NewFromConfig(config)
+	// Conserver la configuration du provider pour créer les clients de la région de la ressource
+	r.meta = meta
 }
 
 // Schema définit la structure et la documentation de la ressource.
@@ -96,9 +109,13 @@ func (r *StartBuildResource) Schema(ctx context.Context, req resource.SchemaRequ
 		MarkdownDescription: "The `test_codebuild_start_build` resource allows you to start a build using AWS CodeBuild. This resource supports configuring environment variables.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Computed: true,
+				Computed:            true,
 				MarkdownDescription: "Identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
+			"region": region.ResourceAttribute(),
 			"project_name": schema.StringAttribute{
 				MarkdownDescription: "The name of the CodeBuild project to start a build for.",
 				Required:            true,
@@ -106,26 +123,51 @@ func (r *StartBuildResource) Schema(ctx context.Context, req resource.SchemaRequ
 			"build_id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the build.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"build_arn": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ARN of the build.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"build_number": schema.Int64Attribute{
 				Computed:            true,
 				MarkdownDescription: "The build number.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"build_project_name": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The name of the project.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"build_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the build (IN_PROGRESS, SUCCEEDED, FAILED, FAULT, TIMED_OUT or STOPPED). Always `IN_PROGRESS` unless `wait_for_completion` is enabled.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"build_image": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The image used for the build environment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"build_environment_variables": schema.ListNestedAttribute{
 				Computed:            true,
 				MarkdownDescription: "The environment variables for the build.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"name": schema.StringAttribute{
@@ -143,13 +185,18 @@ func (r *StartBuildResource) Schema(ctx context.Context, req resource.SchemaRequ
 					},
 				},
 			},
-			"triggers": schema.MapAttribute{
-				ElementType:         types.StringType,
-				MarkdownDescription: "A map of arbitrary strings that, when changed, will force the resource to be recreated.",
+			"triggers":         triggers.ResourceAttribute(),
+			"triggers_replace": triggers.ReplaceResourceAttribute(),
+			"wait_for_completion": schema.BoolAttribute{
+				MarkdownDescription: "Whether to wait for the build to complete before returning. The maximum wait is configured in the `timeouts` block. The final status is available in `build_status` and a failed build is reported according to `failure_mode`. Defaults to `false`.",
 				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
 			},
+			"failure_mode": failuremode.ResourceAttribute(),
 		},
 		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(startBuildTimeouts),
 			"environment_variables": schema.ListNestedBlock{
 				MarkdownDescription: "The environment variables to pass to the build.",
 				NestedObject: schema.NestedBlockObject{
@@ -183,43 +230,28 @@ func (r *StartBuildResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	// Valider l'existence des paramètres PARAMETER_STORE et SECRETS_MANAGER
-	validationDiag := r.validateEnvironmentVariables(ctx, data)
-	if validationDiag.HasError() {
-		resp.Diagnostics.Append(validationDiag...)
-		return
-	}
+	// Utiliser le client CodeBuild de la région de la ressource
+	conn := r.meta.CodeBuildClient(data.Region.ValueString())
 
-	// Construire les variables d'environnement
-	environmentVariables, diag := r.buildEnvironmentVariables(ctx, data)
+	// Récupérer le délai d'attente de la fin du build
+	createTimeout, diag := timeouts.Create(data.Timeouts, startBuildTimeouts)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	// Construire l'input pour StartBuild
-	input := &codebuild.StartBuildInput{
-		ProjectName: aws.String(data.ProjectName.ValueString()),
-	}
-
-	// Ajouter les variables d'environnement si spécifiées
-	if len(environmentVariables) > 0 {
-		input.EnvironmentVariablesOverride = environmentVariables
-	}
-
 	// Démarrer le build
-	output, err := r.codebuild.StartBuild(ctx, input)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to start CodeBuild project",
-			fmt.Sprintf("Error calling AWS CodeBuild StartBuild API for project '%s': %s. Please verify your AWS credentials, permissions, and that the CodeBuild project exists and is accessible.", data.ProjectName.ValueString(), err),
-		)
+	resp.Diagnostics.Append(r.startBuild(ctx, conn, &data)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Mapper les données de retour
-	data.Id = types.StringValue(*output.Build.Id)
-	r.mapBuildToModel(ctx, output.Build, &data)
+	// Attendre la fin du build si demandé. Une fois le build démarré, l'état est enregistré
+	// même en cas d'erreur (délai dépassé, failure_mode = "error") : Terraform marque alors
+	// la ressource comme tainted.
+	if data.WaitForCompletion.ValueBool() {
+		resp.Diagnostics.Append(r.waitForBuild(ctx, conn, &data, createTimeout)...)
+	}
 
 	// Normaliser les valeurs optionnelles
 	r.normalizeOptionalValues(&data)
@@ -244,7 +276,9 @@ func (r *StartBuildResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update gère les modifications de la ressource.
 // Cette méthode est appelée par Terraform lors de la modification d'une ressource existante.
-// Elle vérifie si les triggers ont changé et exécute un nouveau build si nécessaire.
+// Un changement de triggers ou de triggers_replace entraîne le remplacement de la ressource :
+// une mise à jour ne démarre donc jamais de build et enregistre simplement le plan, dont
+// les valeurs calculées sont conservées par les plan modifiers UseStateForUnknown.
 func (r *StartBuildResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data StartBuildResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -252,30 +286,31 @@ func (r *StartBuildResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	// Récupérer l'état actuel pour comparer les triggers
-	var currentData StartBuildResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &currentData)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
 
-	// Vérifier si les triggers ont changé
-	triggersChanged := !data.Triggers.Equal(currentData.Triggers)
+// Delete gère la suppression de la ressource.
+// Cette méthode est appelée par Terraform lors de la suppression d'une ressource.
+// Pour cette ressource, on ne fait rien car les builds CodeBuild ne peuvent pas être supprimés.
+func (r *StartBuildResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Les builds CodeBuild ne peuvent pas être supprimés, on ne fait rien
+}
 
-	if triggersChanged {
+// startBuild valide les variables d'environnement puis démarre un build du projet et mappe
+// le build démarré vers le modèle.
+func (r *StartBuildResource) startBuild(ctx context.Context, conn *codebuild.Client, data *StartBuildResourceModel) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
 
 	// Valider l'existence des paramètres PARAMETER_STORE et SECRETS_MANAGER
-	validationDiag := r.validateEnvironmentVariables(ctx, data)
+	validationDiag := r.validateEnvironmentVariables(ctx, *data)
 	if validationDiag.HasError() {
-		resp.Diagnostics.Append(validationDiag...)
-		return
+		return validationDiag
 	}
 
 	// Construire les variables d'environnement
-	environmentVariables, diag := r.buildEnvironmentVariables(ctx, data)
+	environmentVariables, diag := r.buildEnvironmentVariables(ctx, *data)
 	if diag.HasError() {
-		resp.Diagnostics.Append(diag...)
-		return
+		return diag
 	}
 
 	// Construire l'input pour StartBuild
@@ -289,56 +324,20 @@ func (r *StartBuildResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 
 	// Démarrer le build
-	output, err := r.codebuild.StartBuild(ctx, input)
+	output, err := conn.StartBuild(ctx, input)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diagnostics.AddError(
 			"Unable to start CodeBuild project",
 			fmt.Sprintf("Error calling AWS CodeBuild StartBuild API for project '%s': %s. Please verify your AWS credentials, permissions, and that the CodeBuild project exists and is accessible.", data.ProjectName.ValueString(), err),
 		)
-		return
+		return diagnostics
 	}
 
 	// Mapper les données de retour
 	data.Id = types.StringValue(*output.Build.Id)
-	r.mapBuildToModel(ctx, output.Build, &data)
+	r.mapBuildToModel(ctx, output.Build, data)
 
-	// Normaliser les valeurs optionnelles
-	r.normalizeOptionalValues(&data)
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-	} else {
-		// Si les triggers n'ont pas changé, préserver les valeurs calculées
-		if data.Id.IsUnknown() || data.Id.IsNull() {
-			data.Id = currentData.Id
-		}
-		if data.BuildId.IsUnknown() || data.BuildId.IsNull() {
-			data.BuildId = currentData.BuildId
-		}
-		if data.BuildArn.IsUnknown() || data.BuildArn.IsNull() {
-			data.BuildArn = currentData.BuildArn
-		}
-		if data.BuildNumber.IsUnknown() || data.BuildNumber.IsNull() {
-			data.BuildNumber = currentData.BuildNumber
-		}
-		if data.BuildProjectName.IsUnknown() || data.BuildProjectName.IsNull() {
-			data.BuildProjectName = currentData.BuildProjectName
-		}
-		if data.BuildImage.IsUnknown() || data.BuildImage.IsNull() {
-			data.BuildImage = currentData.BuildImage
-		}
-		if data.BuildEnvironmentVariables.IsUnknown() || data.BuildEnvironmentVariables.IsNull() {
-			data.BuildEnvironmentVariables = currentData.BuildEnvironmentVariables
-		}
-
-		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-	}
-}
-
-// Delete gère la suppression de la ressource.
-// Cette méthode est appelée par Terraform lors de la suppression d'une ressource.
-// Pour cette ressource, on ne fait rien car les builds CodeBuild ne peuvent pas être supprimés.
-func (r *StartBuildResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// Les builds CodeBuild ne peuvent pas être supprimés, on ne fait rien
+	return diagnostics
 }
 
 // buildEnvironmentVariables construit les variables d'environnement pour l'API CodeBuild
@@ -387,6 +386,7 @@ func (r *StartBuildResource) mapBuildToModel(ctx context.Context, build *codebui
 	data.BuildArn = types.StringValue(*build.Arn)
 	data.BuildNumber = types.Int64Value(int64(*build.BuildNumber))
 	data.BuildProjectName = types.StringValue(*build.ProjectName)
+	data.BuildStatus = types.StringValue(string(build.BuildStatus))
 
 	// Mapper l'environnement
 	if build.Environment != nil {
@@ -447,9 +447,85 @@ func (r *StartBuildResource) mapBuildToModel(ctx context.Context, build *codebui
 
 }
 
+// waitForBuild attend la fin du build pendant au plus timeout et met à jour le modèle
+// avec le build terminé. Un build en échec est signalé selon failure_mode.
+func (r *StartBuildResource) waitForBuild(ctx context.Context, conn *codebuild.Client, data *StartBuildResourceModel, timeout time.Duration) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+	buildId := data.BuildId.ValueString()
+
+	build, err := waiter.Wait(ctx, waiter.Config[*codebuildtypes.Build]{
+		Operation: fmt.Sprintf("CodeBuild build '%s'", buildId),
+		Pending:   []string{string(codebuildtypes.StatusTypeInProgress)},
+		Target: []string{
+			string(codebuildtypes.StatusTypeSucceeded),
+			string(codebuildtypes.StatusTypeFailed),
+			string(codebuildtypes.StatusTypeFault),
+			string(codebuildtypes.StatusTypeTimedOut),
+			string(codebuildtypes.StatusTypeStopped),
+		},
+		Refresh: func(ctx context.Context) (*codebuildtypes.Build, string, error) {
+			output, err := conn.BatchGetBuilds(ctx, &codebuild.BatchGetBuildsInput{
+				Ids: []string{buildId},
+			})
+			if err != nil {
+				return nil, "", fmt.Errorf("calling AWS CodeBuild BatchGetBuilds API: %w", err)
+			}
+			if len(output.Builds) == 0 {
+				return nil, "", fmt.Errorf("build '%s' not found", buildId)
+			}
+			return &output.Builds[0], string(output.Builds[0].BuildStatus), nil
+		},
+		Timeout:  timeout,
+		MinDelay: 5 * time.Second,
+	})
+	if err != nil {
+		diagnostics.AddError(
+			"Unable to wait for CodeBuild build to complete",
+			fmt.Sprintf("Error while waiting on build '%s': %s. The build may still be running; check its status in the CodeBuild console.", buildId, err),
+		)
+		return diagnostics
+	}
+
+	r.mapBuildToModel(ctx, build, data)
+
+	// Signaler l'échec du build selon failure_mode
+	if build.BuildStatus != codebuildtypes.StatusTypeSucceeded {
+		diagnostics.Append(failuremode.Diagnostics(data.FailureMode, "CodeBuild build failed", buildFailureDetail(build))...)
+	}
+
+	return diagnostics
+}
+
+// buildFailureDetail décrit un build en échec pour le diagnostic de failure_mode :
+// les phases en échec avec leurs messages et le lien vers les logs du build.
+func buildFailureDetail(build *codebuildtypes.Build) string {
+	var detail strings.Builder
+	fmt.Fprintf(&detail, "Build '%s' completed with status '%s'.", aws.ToString(build.Id), build.BuildStatus)
+
+	for _, phase := range build.Phases {
+		if phase.PhaseStatus == "" || phase.PhaseStatus == codebuildtypes.StatusTypeSucceeded {
+			continue
+		}
+		fmt.Fprintf(&detail, "\n\nPhase %s: %s", phase.PhaseType, phase.PhaseStatus)
+		for _, phaseContext := range phase.Contexts {
+			fmt.Fprintf(&detail, "\n  %s: %s", aws.ToString(phaseContext.StatusCode), aws.ToString(phaseContext.Message))
+		}
+	}
+
+	if build.Logs != nil && build.Logs.DeepLink != nil {
+		fmt.Fprintf(&detail, "\n\nLogs: %s", aws.ToString(build.Logs.DeepLink))
+	}
+
+	return detail.String()
+}
+
 // validateEnvironmentVariables valide l'existence des paramètres PARAMETER_STORE et SECRETS_MANAGER
 func (r *StartBuildResource) validateEnvironmentVariables(ctx context.Context, data StartBuildResourceModel) diag.Diagnostics {
 	var diagnostics diag.Diagnostics
+
+	// Utiliser les clients de la région de la ressource
+	ssmConn := r.meta.SSMClient(data.Region.ValueString())
+	secretsConn := r.meta.SecretsManagerClient(data.Region.ValueString())
 	
 	for _, envVar := range data.EnvironmentVariables {
 		if envVar.Type.IsNull() || envVar.Type.ValueString() == "PLAINTEXT" {
@@ -459,7 +535,7 @@ func (r *StartBuildResource) validateEnvironmentVariables(ctx context.Context, d
 		switch envVar.Type.ValueString() {
 		case "PARAMETER_STORE":
 			// Vérifier que le paramètre SSM existe
-			_, err := r.ssm.GetParameter(ctx, &ssm.GetParameterInput{
+			_, err := ssmConn.GetParameter(ctx, &ssm.GetParameterInput{
 				Name:           aws.String(envVar.Value.ValueString()),
 				WithDecryption: aws.Bool(false), // Pas besoin de décrypter pour vérifier l'existence
 			})
@@ -473,7 +549,7 @@ func (r *StartBuildResource) validateEnvironmentVariables(ctx context.Context, d
 			
 		case "SECRETS_MANAGER":
 			// Vérifier que le secret existe
-			_, err := r.secrets.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+			_, err := secretsConn.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
 				SecretId: aws.String(envVar.Value.ValueString()),
 			})
 			if err != nil {
@@ -0,0 +1,194 @@
+package conns
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/codebuild"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// ProviderMeta contient les données transmises par le provider aux ressources et data sources
+// lors de leur configuration : la configuration AWS partagée, les paramètres globaux du provider
+// et un cache de clients AWS. Les clients sont créés à la première utilisation et partagés
+// par toutes les ressources, avec un client par couple service/région.
+type ProviderMeta struct {
+	// Config est la configuration AWS résolue (région, credentials, retries, transport).
+	Config aws.Config
+
+	// DefaultTags contient les tags du bloc default_tags du provider.
+	// Ils sont fusionnés avec les tags de chaque ressource taggable.
+	DefaultTags map[string]string
+
+	// Endpoints associe le nom d'un service (ex: "ssm") à l'URL personnalisée définie dans
+	// le bloc endpoints du provider. Les clients de ces services utilisent cette URL.
+	Endpoints map[string]string
+
+	mu        sync.Mutex
+	clients   map[clientKey]any
+	accountID string
+}
+
+// clientKey identifie un client AWS mis en cache.
+type clientKey struct {
+	service string
+	region  string
+}
+
+// FromProviderData convertit la donnée transmise par le provider à Configure.
+// Le résultat est nil (sans erreur) lorsque le provider n'a pas encore été configuré,
+// ce qui arrive pendant la validation de la configuration.
+func FromProviderData(providerData any, typeName string) (*ProviderMeta, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	// Éviter le panic si le provider n'a pas été configuré
+	if providerData == nil {
+		return nil, diagnostics
+	}
+
+	// Vérifier que la configuration est du bon type
+	meta, ok := providerData.(*ProviderMeta)
+	if !ok {
+		diagnostics.AddError(
+			"Provider configuration error",
+			fmt.Sprintf("Expected *conns.ProviderMeta for %s, got: %T. This indicates a provider configuration issue. Please verify your provider configuration and report this issue if it persists.", typeName, providerData),
+		)
+		return nil, diagnostics
+	}
+
+	return meta, diagnostics
+}
+
+// Region retourne la région à utiliser : la région surchargée par la ressource
+// si elle est définie, sinon la région du provider.
+func (m *ProviderMeta) Region(override string) string {
+	if override != "" {
+		return override
+	}
+	return m.Config.Region
+}
+
+// AccountID retourne l'identifiant du compte AWS des credentials du provider.
+// Il est résolu avec STS GetCallerIdentity au premier appel puis mis en cache.
+func (m *ProviderMeta) AccountID(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	accountID := m.accountID
+	m.mu.Unlock()
+	if accountID != "" {
+		return accountID, nil
+	}
+
+	identity, err := m.STSClient("").GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", err
+	}
+
+	m.mu.Lock()
+	m.accountID = aws.ToString(identity.Account)
+	m.mu.Unlock()
+	return aws.ToString(identity.Account), nil
+}
+
+// CloudWatchClient retourne le client CloudWatch de la région donnée (région du provider si vide).
+func (m *ProviderMeta) CloudWatchClient(region string) *cloudwatch.Client {
+	return cachedClient(m, "cloudwatch", region, func(cfg aws.Config) *cloudwatch.Client {
+		return cloudwatch.NewFromConfig(cfg, func(o *cloudwatch.Options) {
+			o.BaseEndpoint = m.endpoint("cloudwatch")
+		})
+	})
+}
+
+// CodeBuildClient retourne le client CodeBuild de la région donnée (région du provider si vide).
+func (m *ProviderMeta) CodeBuildClient(region string) *codebuild.Client {
+	return cachedClient(m, "codebuild", region, func(cfg aws.Config) *codebuild.Client {
+		return codebuild.NewFromConfig(cfg, func(o *codebuild.Options) {
+			o.BaseEndpoint = m.endpoint("codebuild")
+		})
+	})
+}
+
+// DynamoDBClient retourne le client DynamoDB de la région donnée (région du provider si vide).
+func (m *ProviderMeta) DynamoDBClient(region string) *dynamodb.Client {
+	return cachedClient(m, "dynamodb", region, func(cfg aws.Config) *dynamodb.Client {
+		return dynamodb.NewFromConfig(cfg, func(o *dynamodb.Options) {
+			o.BaseEndpoint = m.endpoint("dynamodb")
+		})
+	})
+}
+
+// SecretsManagerClient retourne le client Secrets Manager de la région donnée (région du provider si vide).
+func (m *ProviderMeta) SecretsManagerClient(region string) *secretsmanager.Client {
+	return cachedClient(m, "secretsmanager", region, func(cfg aws.Config) *secretsmanager.Client {
+		return secretsmanager.NewFromConfig(cfg, func(o *secretsmanager.Options) {
+			o.BaseEndpoint = m.endpoint("secretsmanager")
+		})
+	})
+}
+
+// SFNClient retourne le client Step Functions de la région donnée (région du provider si vide).
+func (m *ProviderMeta) SFNClient(region string) *sfn.Client {
+	return cachedClient(m, "sfn", region, func(cfg aws.Config) *sfn.Client {
+		return sfn.NewFromConfig(cfg, func(o *sfn.Options) {
+			o.BaseEndpoint = m.endpoint("sfn")
+		})
+	})
+}
+
+// SSMClient retourne le client SSM de la région donnée (région du provider si vide).
+func (m *ProviderMeta) SSMClient(region string) *ssm.Client {
+	return cachedClient(m, "ssm", region, func(cfg aws.Config) *ssm.Client {
+		return ssm.NewFromConfig(cfg, func(o *ssm.Options) {
+			o.BaseEndpoint = m.endpoint("ssm")
+		})
+	})
+}
+
+// STSClient retourne le client STS de la région donnée (région du provider si vide).
+func (m *ProviderMeta) STSClient(region string) *sts.Client {
+	return cachedClient(m, "sts", region, func(cfg aws.Config) *sts.Client {
+		return sts.NewFromConfig(cfg, func(o *sts.Options) {
+			o.BaseEndpoint = m.endpoint("sts")
+		})
+	})
+}
+
+// endpoint retourne l'URL personnalisée du service donné, ou nil pour utiliser l'endpoint
+// par défaut du SDK AWS.
+func (m *ProviderMeta) endpoint(service string) *string {
+	if endpoint, ok := m.Endpoints[service]; ok {
+		return aws.String(endpoint)
+	}
+	return nil
+}
+
+// cachedClient retourne le client mis en cache pour le service et la région donnés,
+// ou le crée avec la configuration du provider (région surchargée si besoin).
+func cachedClient[T any](m *ProviderMeta, service, region string, build func(aws.Config) T) T {
+	region = m.Region(region)
+	key := clientKey{service: service, region: region}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if client, ok := m.clients[key]; ok {
+		return client.(T)
+	}
+
+	cfg := m.Config.Copy()
+	cfg.Region = region
+	client := build(cfg)
+
+	if m.clients == nil {
+		m.clients = map[clientKey]any{}
+	}
+	m.clients[key] = client
+	return client
+}
@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// awsLoadOptions construit les options passées à awsconfig.LoadDefaultConfig à partir
+// de la configuration du provider. La configuration est chargée en une seule fois et
+// les credentials sont résolus dans l'ordre de précédence suivant :
+//
+//  1. access_key / secret_key / token définis dans le provider
+//  2. profile défini dans le provider (lu depuis shared_config_files / shared_credentials_files)
+//  3. variables d'environnement (AWS_ACCESS_KEY_ID, AWS_PROFILE, AWS_WEB_IDENTITY_TOKEN_FILE, ...)
+//  4. profil par défaut des fichiers partagés (y compris credential_process, SSO et source_profile)
+//  5. rôle du conteneur ECS ou de l'instance EC2
+//
+// La région suit la même logique : region du provider, puis AWS_REGION, puis la région du profil.
+func awsLoadOptions(ctx context.Context, config TestProviderModel) ([]func(*awsconfig.LoadOptions) error, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+	var options []func(*awsconfig.LoadOptions) error
+
+	if !config.Region.IsNull() {
+		options = append(options, awsconfig.WithRegion(config.Region.ValueString()))
+	}
+
+	// Fichiers partagés personnalisés
+	if !config.SharedConfigFiles.IsNull() {
+		files, diags := expandPaths(ctx, config.SharedConfigFiles)
+		diagnostics.Append(diags...)
+		if len(files) > 0 {
+			options = append(options, awsconfig.WithSharedConfigFiles(files))
+		}
+	}
+	if !config.SharedCredentialsFiles.IsNull() {
+		files, diags := expandPaths(ctx, config.SharedCredentialsFiles)
+		diagnostics.Append(diags...)
+		if len(files) > 0 {
+			options = append(options, awsconfig.WithSharedCredentialsFiles(files))
+		}
+	}
+
+	if !config.Profile.IsNull() && config.Profile.ValueString() != "" {
+		options = append(options, awsconfig.WithSharedConfigProfile(config.Profile.ValueString()))
+	}
+
+	// Credentials statiques : ils remplacent toute autre source de credentials
+	accessKey := config.AccessKey.ValueString()
+	secretKey := config.SecretKey.ValueString()
+	switch {
+	case accessKey != "" && secretKey != "":
+		options = append(options, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(accessKey, secretKey, config.Token.ValueString()),
+		))
+	case accessKey != "" || secretKey != "":
+		diagnostics.AddError(
+			"Invalid static credentials configuration",
+			"Both access_key and secret_key must be set when using static credentials. Please provide the missing value or remove both to use another credential source.",
+		)
+	case config.Token.ValueString() != "":
+		diagnostics.AddError(
+			"Invalid static credentials configuration",
+			"The token attribute can only be used together with access_key and secret_key. Please provide both keys or remove the token.",
+		)
+	}
+
+	// Retries, limitation de débit et transport HTTP
+	transportOptions, diags := transportLoadOptions(ctx, config)
+	diagnostics.Append(diags...)
+	options = append(options, transportOptions...)
+
+	return options, diagnostics
+}
+
+// expandPaths convertit une liste Terraform de chemins en chemins absolus,
+// en remplaçant le préfixe `~` par le répertoire personnel de l'utilisateur.
+func expandPaths(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	paths := make([]string, 0, len(list.Elements()))
+	diagnostics.Append(list.ElementsAs(ctx, &paths, false)...)
+	if diagnostics.HasError() {
+		return nil, diagnostics
+	}
+
+	for i, path := range paths {
+		expanded, err := expandHomePath(path)
+		if err != nil {
+			diagnostics.AddError(
+				"Unable to expand shared file path",
+				"Error resolving the home directory for path '"+path+"': "+err.Error()+". Please use an absolute path instead.",
+			)
+			return nil, diagnostics
+		}
+		paths[i] = expanded
+	}
+
+	return paths, diagnostics
+}
+
+// expandHomePath remplace le préfixe `~` d'un chemin par le répertoire personnel de l'utilisateur.
+func expandHomePath(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
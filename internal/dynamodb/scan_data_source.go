@@ -13,6 +13,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+	"github.com/jd-ucpa/terraform-provider-test/internal/region"
 )
 
 // Ensure ScanDataSource satisfies various datasource interfaces.
@@ -27,7 +29,7 @@ func NewScanDataSource() datasource.DataSource {
 
 // ScanDataSource récupère les éléments d'une table DynamoDB via l'opération Scan.
 type ScanDataSource struct {
-	dynamodb *dynamodb.Client
+	meta *conns.ProviderMeta
 }
 
 // ScanDataSourceModel définit le modèle de données pour le data source
@@ -40,6 +42,7 @@ type ScanDataSourceModel struct {
 	ExpressionAttributeNames  map[string]types.String `tfsdk:"expression_attribute_names"`
 	ExpressionAttributeValues map[string]types.String `tfsdk:"expression_attribute_values"`
 	ID                        types.String            `tfsdk:"id"`
+	Region                    types.String            `tfsdk:"region"`
 	Items                     types.List              `tfsdk:"items"`
 	ItemsCount                types.Int64             `tfsdk:"items_count"`
 	ScannedCount              types.Int64             `tfsdk:"scanned_count"`
@@ -52,23 +55,14 @@ func (d *ScanDataSource) Metadata(ctx context.Context, req datasource.MetadataRe
 
 // Configure initialise le client DynamoDB à partir de la configuration du provider.
 func (d *ScanDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	// Éviter le panic si le provider n'a pas été configuré
-	if req.ProviderData == nil {
+	meta, diags := conns.FromProviderData(req.ProviderData, "DynamoDB scan data source")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
 		return
 	}
 
-	// Vérifier que la configuration est du bon type
-	config, ok := req.ProviderData.(aws.Config)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Provider configuration error",
-			fmt.Sprintf("Expected aws.Config for DynamoDB scan data source, got: %T. This indicates a provider configuration issue. Please verify your provider configuration and report this issue if it persists.", req.ProviderData),
-		)
-		return
-	}
-	
-	// Créer le client DynamoDB à partir de la configuration AWS
-	d.dynamodb = dynamodb.NewFromConfig(config)
+	// Conserver la configuration du provider pour créer les clients de la région demandée
+	d.meta = meta
 }
 
 // Schema définit la structure et la documentation du data source.
@@ -107,6 +101,7 @@ func (d *ScanDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				MarkdownDescription: "The ID of the data source.",
 				Computed:            true,
 			},
+			"region": region.DataSourceAttribute(),
 			"items": schema.ListAttribute{
 				ElementType:         types.MapType{ElemType: types.StringType},
 				MarkdownDescription: "List of items retrieved from the table.",
@@ -134,6 +129,9 @@ func (d *ScanDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
+	// Utiliser le client de la région de la configuration
+	conn := d.meta.DynamoDBClient(data.Region.ValueString())
+
 	// Construire les paramètres pour l'API
 	input := &dynamodb.ScanInput{
 		TableName: aws.String(data.TableName.ValueString()),
@@ -220,7 +218,7 @@ func (d *ScanDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 
 	// Appeler l'API DynamoDB
-	result, err := d.dynamodb.Scan(ctx, input)
+	result, err := conn.Scan(ctx, input)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to scan DynamoDB table",
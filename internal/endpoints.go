@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// EndpointsModel définit les URLs personnalisées à utiliser pour chaque service AWS.
+// Il permet de pointer le provider vers un émulateur local (LocalStack, moto, etc.)
+// au lieu des endpoints AWS publics.
+type EndpointsModel struct {
+	CloudWatch     types.String `tfsdk:"cloudwatch"`
+	CodeBuild      types.String `tfsdk:"codebuild"`
+	DynamoDB       types.String `tfsdk:"dynamodb"`
+	SecretsManager types.String `tfsdk:"secretsmanager"`
+	SFN            types.String `tfsdk:"sfn"`
+	SSM            types.String `tfsdk:"ssm"`
+	STS            types.String `tfsdk:"sts"`
+}
+
+// serviceIDs associe le nom de chaque service configurable dans le provider
+// (bloc rate_limits) au ServiceID du package correspondant du SDK AWS.
+var serviceIDs = map[string]string{
+	"cloudwatch":     "CloudWatch",
+	"codebuild":      "CodeBuild",
+	"dynamodb":       "DynamoDB",
+	"secretsmanager": "Secrets Manager",
+	"sfn":            "SFN",
+	"ssm":            "SSM",
+	"sts":            "STS",
+}
+
+// endpointsSchemaBlock retourne le bloc `endpoints` du schéma du provider.
+// Chaque attribut correspond à un service utilisé par les ressources et data sources.
+func endpointsSchemaBlock() schema.Block {
+	attributes := map[string]schema.Attribute{}
+	for _, name := range []string{"cloudwatch", "codebuild", "dynamodb", "secretsmanager", "sfn", "ssm", "sts"} {
+		attributes[name] = schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("Use this to override the default service endpoint URL for `%s`.", name),
+			Description:         fmt.Sprintf("Use this to override the default service endpoint URL for %s.", name),
+		}
+	}
+
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Configuration block for customizing service endpoints, e.g. to target a local AWS emulator.",
+		Attributes:          attributes,
+	}
+}
+
+// serviceEndpoints convertit le bloc endpoints en une map indexée par le nom du service
+// dans le bloc (ssm, codebuild, ...). Les URLs sont validées afin de produire une erreur
+// explicite lors de la configuration du provider.
+func (m *EndpointsModel) serviceEndpoints() (map[string]string, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	endpoints := map[string]string{}
+	if m == nil {
+		return endpoints, diagnostics
+	}
+
+	candidates := []struct {
+		attribute string
+		value     types.String
+	}{
+		{"cloudwatch", m.CloudWatch},
+		{"codebuild", m.CodeBuild},
+		{"dynamodb", m.DynamoDB},
+		{"secretsmanager", m.SecretsManager},
+		{"sfn", m.SFN},
+		{"ssm", m.SSM},
+		{"sts", m.STS},
+	}
+
+	for _, candidate := range candidates {
+		if candidate.value.IsNull() || candidate.value.IsUnknown() || candidate.value.ValueString() == "" {
+			continue
+		}
+
+		endpoint := candidate.value.ValueString()
+		parsed, err := url.Parse(endpoint)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			diagnostics.AddError(
+				"Invalid endpoint configuration",
+				fmt.Sprintf(`"endpoints.%s" (%s) is an invalid URL: the endpoint must be an absolute http:// or https:// URL.`, candidate.attribute, endpoint),
+			)
+			continue
+		}
+
+		endpoints[candidate.attribute] = endpoint
+	}
+
+	return endpoints, diagnostics
+}
@@ -0,0 +1,84 @@
+// Package failuremode fournit l'attribut failure_mode commun aux ressources qui lancent
+// une opération (commande SSM, build CodeBuild, exécution Step Functions) et enregistrent
+// son statut final. Il détermine si un échec de l'opération fait échouer l'apply.
+package failuremode
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Valeurs possibles de l'attribut failure_mode.
+const (
+	// Error fait échouer l'apply ; la ressource créée est marquée comme tainted.
+	Error = "error"
+	// Warn signale l'échec par un avertissement.
+	Warn = "warn"
+	// Ignore enregistre seulement le statut (comportement historique des ressources).
+	Ignore = "ignore"
+)
+
+// modes liste les valeurs acceptées par l'attribut failure_mode.
+var modes = []string{Error, Warn, Ignore}
+
+// ResourceAttribute retourne l'attribut failure_mode d'une ressource.
+func ResourceAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "How a failed run is reported. `error` fails the apply with the failure details (output, error, cause) and marks a newly created resource as tainted, so that it is run again on the next apply. `warn` reports the failure details as a warning. `ignore` only records the final status. Defaults to `ignore`.",
+		Optional:            true,
+		Computed:            true,
+		Default:             stringdefault.StaticString(Ignore),
+		Validators: []validator.String{
+			modeValidator{},
+		},
+	}
+}
+
+// Diagnostics retourne le diagnostic qui signale l'échec d'une opération selon le mode
+// configuré : une erreur pour "error", un avertissement pour "warn" et aucun diagnostic
+// pour "ignore".
+func Diagnostics(mode types.String, summary, detail string) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	switch mode.ValueString() {
+	case Error:
+		diagnostics.AddError(summary, detail)
+	case Warn:
+		diagnostics.AddWarning(summary, detail)
+	}
+
+	return diagnostics
+}
+
+// modeValidator vérifie au moment du plan que failure_mode a une valeur connue.
+type modeValidator struct{}
+
+func (v modeValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(modes, ", "))
+}
+
+func (v modeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v modeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !slices.Contains(modes, req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid failure_mode",
+			fmt.Sprintf("failure_mode must be one of %s, got '%s'.", strings.Join(modes, ", "), req.ConfigValue.ValueString()),
+		)
+	}
+}
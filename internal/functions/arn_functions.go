@@ -0,0 +1,171 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/partition"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ function.Function = &ARNParseFunction{}
+	_ function.Function = &ARNBuildFunction{}
+)
+
+// arnAttributeTypes décrit l'objet retourné par arn_parse.
+var arnAttributeTypes = map[string]attr.Type{
+	"partition":  types.StringType,
+	"service":    types.StringType,
+	"region":     types.StringType,
+	"account_id": types.StringType,
+	"resource":   types.StringType,
+}
+
+// ARNModel définit le modèle de l'objet retourné par la fonction arn_parse.
+// Les composants absents de l'ARN (région ou compte des services globaux) sont des chaînes vides.
+type ARNModel struct {
+	Partition types.String `tfsdk:"partition"`
+	Service   types.String `tfsdk:"service"`
+	Region    types.String `tfsdk:"region"`
+	AccountId types.String `tfsdk:"account_id"`
+	Resource  types.String `tfsdk:"resource"`
+}
+
+// NewARNParseFunction crée et retourne une nouvelle instance de la fonction
+// ARNParseFunction. Cette fonction est utilisée par le provider pour enregistrer
+// la fonction provider::test::arn_parse dans Terraform.
+func NewARNParseFunction() function.Function {
+	return &ARNParseFunction{}
+}
+
+// NewARNBuildFunction crée et retourne une nouvelle instance de la fonction
+// ARNBuildFunction. Cette fonction est utilisée par le provider pour enregistrer
+// la fonction provider::test::arn_build dans Terraform.
+func NewARNBuildFunction() function.Function {
+	return &ARNBuildFunction{}
+}
+
+// ARNParseFunction décompose un ARN en ses composants après l'avoir validé
+// avec les mêmes règles que les ARN de la configuration du provider.
+type ARNParseFunction struct{}
+
+// ARNBuildFunction construit un ARN à partir de ses composants et le valide
+// avec les mêmes règles que les ARN de la configuration du provider.
+type ARNBuildFunction struct{}
+
+// Metadata définit le nom de la fonction utilisé dans les configurations Terraform.
+func (f *ARNParseFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "arn_parse"
+}
+
+// Definition définit les paramètres, le type de retour et la documentation de la fonction.
+func (f *ARNParseFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Parses an ARN into its components.",
+		MarkdownDescription: "Parses an Amazon Resource Name (ARN) into an object with the `partition`, `service`, `region`, `account_id` and `resource` attributes. Components that are empty in the ARN (e.g. the region of IAM or S3 ARNs) are returned as empty strings. The ARN must use a known partition, a region of that partition (if any) and an account ID (if any) that is either a 12-digit account ID or `aws` for resources managed by AWS such as AWS managed IAM policies.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "arn",
+				MarkdownDescription: "The ARN to parse, e.g. `arn:aws:states:eu-west-1:123456789012:stateMachine:my-state-machine`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: arnAttributeTypes,
+		},
+	}
+}
+
+// Run valide l'ARN puis retourne ses composants.
+func (f *ARNParseFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	if err := partition.ValidateARN(value, ""); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("%q is an invalid ARN: %s", value, err))
+		return
+	}
+
+	parsed, _ := arn.Parse(value)
+	result := ARNModel{
+		Partition: types.StringValue(parsed.Partition),
+		Service:   types.StringValue(parsed.Service),
+		Region:    types.StringValue(parsed.Region),
+		AccountId: types.StringValue(parsed.AccountID),
+		Resource:  types.StringValue(parsed.Resource),
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
+
+// Metadata définit le nom de la fonction utilisé dans les configurations Terraform.
+func (f *ARNBuildFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "arn_build"
+}
+
+// Definition définit les paramètres, le type de retour et la documentation de la fonction.
+func (f *ARNBuildFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Builds an ARN from its components.",
+		MarkdownDescription: "Builds an Amazon Resource Name (ARN) in the form `arn:PARTITION:SERVICE:REGION:ACCOUNT_ID:RESOURCE`. The result is validated with the same rules as `arn_parse`, so that an invalid partition, service, region or account ID is reported at plan time.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "partition",
+				MarkdownDescription: "The partition of the ARN, e.g. `aws`, `aws-cn` or `aws-us-gov`. Use the `test_partition` data source to get the partition of the provider region.",
+			},
+			function.StringParameter{
+				Name:                "service",
+				MarkdownDescription: "The service namespace, e.g. `states`, `iam` or `cloudwatch`.",
+			},
+			function.StringParameter{
+				Name:                "region",
+				AllowNullValue:      true,
+				MarkdownDescription: "The region of the resource. `null` or an empty string for global services such as IAM.",
+			},
+			function.StringParameter{
+				Name:                "account_id",
+				AllowNullValue:      true,
+				MarkdownDescription: "The 12-digit account ID owning the resource, or `aws` for resources managed by AWS such as AWS managed IAM policies. `null` or an empty string for resources without an account ID such as S3 buckets.",
+			},
+			function.StringParameter{
+				Name:                "resource",
+				MarkdownDescription: "The resource part of the ARN, e.g. `stateMachine:my-state-machine` or `role/my-role`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run construit l'ARN à partir de ses composants puis le valide.
+func (f *ARNBuildFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var partitionID, service, resource string
+	var region, accountID types.String
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &partitionID, &service, &region, &accountID, &resource))
+	if resp.Error != nil {
+		return
+	}
+
+	value := arn.ARN{
+		Partition: partitionID,
+		Service:   service,
+		Region:    region.ValueString(),
+		AccountID: accountID.ValueString(),
+		Resource:  resource,
+	}.String()
+
+	if err := partition.ValidateARN(value, ""); err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("%q is an invalid ARN: %s", value, err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, value))
+}
@@ -0,0 +1,124 @@
+package functions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ function.Function = &JSONPrettyFunction{}
+	_ function.Function = &JSONMinifyFunction{}
+)
+
+// NewJSONPrettyFunction crée et retourne une nouvelle instance de la fonction
+// JSONPrettyFunction. Cette fonction est utilisée par le provider pour enregistrer
+// la fonction provider::test::json_pretty dans Terraform.
+func NewJSONPrettyFunction() function.Function {
+	return &JSONPrettyFunction{}
+}
+
+// NewJSONMinifyFunction crée et retourne une nouvelle instance de la fonction
+// JSONMinifyFunction. Cette fonction est utilisée par le provider pour enregistrer
+// la fonction provider::test::json_minify dans Terraform.
+func NewJSONMinifyFunction() function.Function {
+	return &JSONMinifyFunction{}
+}
+
+// JSONPrettyFunction formate une chaîne JSON avec l'indentation demandée.
+// Contrairement au data source test_json_pretty, l'ordre des clés est conservé.
+type JSONPrettyFunction struct{}
+
+// JSONMinifyFunction supprime les espaces non significatifs d'une chaîne JSON.
+type JSONMinifyFunction struct{}
+
+// Metadata définit le nom de la fonction utilisé dans les configurations Terraform.
+func (f *JSONPrettyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "json_pretty"
+}
+
+// Definition définit les paramètres, le type de retour et la documentation de la fonction.
+func (f *JSONPrettyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Formats a JSON string with the given indentation.",
+		MarkdownDescription: "Formats a JSON string with one element per line, indented with the given number of spaces. Object keys keep their original order and numbers keep their original precision.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "json",
+				MarkdownDescription: "The JSON string to format.",
+			},
+			function.Int64Parameter{
+				Name:                "indent",
+				MarkdownDescription: "Number of spaces to use for indentation. Must be between 0 and 16.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run formate la chaîne JSON avec l'indentation demandée.
+func (f *JSONPrettyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	var indent int64
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &input, &indent))
+	if resp.Error != nil {
+		return
+	}
+
+	if indent < 0 || indent > 16 {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid indent value: %d. The indentation must be between 0 and 16 spaces.", indent))
+		return
+	}
+
+	var buffer bytes.Buffer
+	if err := json.Indent(&buffer, []byte(input), "", strings.Repeat(" ", int(indent))); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Unable to parse JSON string: "+err.Error()+". Please verify the JSON syntax is correct.")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, buffer.String()))
+}
+
+// Metadata définit le nom de la fonction utilisé dans les configurations Terraform.
+func (f *JSONMinifyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "json_minify"
+}
+
+// Definition définit les paramètres, le type de retour et la documentation de la fonction.
+func (f *JSONMinifyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Removes insignificant whitespace from a JSON string.",
+		MarkdownDescription: "Removes insignificant whitespace (spaces, tabs and newlines between tokens) from a JSON string. Object keys keep their original order and numbers keep their original precision.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "json",
+				MarkdownDescription: "The JSON string to minify.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run supprime les espaces non significatifs de la chaîne JSON.
+func (f *JSONMinifyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	var buffer bytes.Buffer
+	if err := json.Compact(&buffer, []byte(input)); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Unable to parse JSON string: "+err.Error()+". Please verify the JSON syntax is correct.")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, buffer.String()))
+}
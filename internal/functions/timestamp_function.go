@@ -0,0 +1,139 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure TimestampFunction satisfies the function interface.
+var _ function.Function = &TimestampFunction{}
+
+// NewTimestampFunction crée et retourne une nouvelle instance de la fonction
+// TimestampFunction. Cette fonction est utilisée par le provider pour enregistrer
+// la fonction provider::test::timestamp dans Terraform.
+func NewTimestampFunction() function.Function {
+	return &TimestampFunction{}
+}
+
+// TimestampFunction convertit un timestamp RFC 3339 dans un fuseau horaire donné et le décale
+// d'une durée optionnelle. Le format est le même que celui du data source test_timestamp :
+// yyyy-mm-ddThh:mm:ss, sans le suffixe 'Z'. La fonction est pure : l'heure de référence est
+// fournie par l'appelant (ex: plantimestamp()) et le data source reste le moyen d'obtenir
+// l'heure actuelle.
+type TimestampFunction struct{}
+
+// Metadata définit le nom de la fonction utilisé dans les configurations Terraform.
+func (f *TimestampFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "timestamp"
+}
+
+// Definition définit les paramètres, le type de retour et la documentation de la fonction.
+func (f *TimestampFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Converts a timestamp to a time zone, shifted by an optional offset.",
+		MarkdownDescription: "Converts an RFC 3339 timestamp to the format `yyyy-mm-ddThh:mm:ss` (without the `Z` suffix) in the given time zone, shifted by the given offset. The function is pure and always returns the same result for the same arguments: pass `plantimestamp()` to get a value based on the current time that stays stable between plan and apply.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "timestamp",
+				MarkdownDescription: "The reference time in RFC 3339 format, e.g. `2024-01-15T10:00:00Z` or the result of `plantimestamp()`.",
+			},
+			function.StringParameter{
+				Name:                "time_zone",
+				AllowNullValue:      true,
+				MarkdownDescription: "The IANA time zone of the result, e.g. `Europe/Paris` or `America/New_York`. `null` or an empty string means UTC.",
+			},
+			function.StringParameter{
+				Name:                "offset",
+				AllowNullValue:      true,
+				MarkdownDescription: "The duration added to the reference time, e.g. `90m`, `-2h30m` or `1d12h`. Units `d`, `h`, `m`, `s`, `ms`, `us` and `ns` are supported and a leading `-` subtracts the duration. `null` or an empty string means no offset.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run calcule le timestamp à partir de l'heure de référence, du fuseau horaire et du décalage demandés.
+func (f *TimestampFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var timestamp string
+	var timeZone, offset types.String
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &timestamp, &timeZone, &offset))
+	if resp.Error != nil {
+		return
+	}
+
+	// Commencer avec l'heure de référence en UTC
+	parsed, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf(`Invalid timestamp: %q is not in RFC 3339 format. Expected a value such as "2024-01-15T10:00:00Z" or the result of plantimestamp().`, timestamp))
+		return
+	}
+	value := parsed.UTC()
+
+	// Appliquer le fuseau horaire si spécifié
+	if zone := timeZone.ValueString(); zone != "" && zone != "UTC" {
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Unable to load timezone '%s': %s. Please verify the timezone string is valid (e.g., 'UTC', 'Europe/Paris', 'America/New_York').", zone, err))
+			return
+		}
+		value = value.In(loc)
+	}
+
+	// Appliquer le décalage si spécifié
+	duration, err := parseOffset(offset.ValueString())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf(`Invalid offset: %s. Expected a duration such as "90m", "-2h30m" or "1d12h".`, err))
+		return
+	}
+	value = value.Add(duration)
+
+	// Formater le timestamp au format yyyy-mm-ddThh:mm:ss (sans le 'Z')
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, value.Format("2006-01-02T15:04:05")))
+}
+
+// parseOffset convertit un décalage en durée. La syntaxe est celle de time.ParseDuration,
+// complétée par l'unité "d" (jours de 24 heures) qui doit être placée en premier.
+// Le signe éventuel s'applique à l'ensemble du décalage (ex: "-1d12h").
+func parseOffset(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	invalid := fmt.Errorf("%q is not a valid duration", value)
+
+	sign := time.Duration(1)
+	rest := value
+	if strings.HasPrefix(rest, "-") {
+		sign = -1
+		rest = rest[1:]
+	} else if strings.HasPrefix(rest, "+") {
+		rest = rest[1:]
+	}
+
+	var total time.Duration
+	if i := strings.Index(rest, "d"); i >= 0 {
+		days, err := strconv.ParseUint(rest[:i], 10, 16)
+		if err != nil {
+			return 0, invalid
+		}
+		total = time.Duration(days) * 24 * time.Hour
+		rest = rest[i+1:]
+	}
+
+	if rest != "" {
+		duration, err := time.ParseDuration(rest)
+		if err != nil || duration < 0 || strings.HasPrefix(rest, "+") {
+			return 0, invalid
+		}
+		total += duration
+	}
+
+	return sign * total, nil
+}
@@ -0,0 +1,217 @@
+package partition
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// Partition décrit une partition AWS (aws, aws-cn, aws-us-gov, partitions isolées).
+// Une partition regroupe un ensemble de régions qui partagent le même préfixe d'ARN
+// et le même suffixe DNS pour les endpoints de service.
+type Partition struct {
+	ID             string
+	Name           string
+	DNSSuffix      string
+	RegionPrefixes []string
+}
+
+// Partitions contient la liste des partitions AWS connues. L'ordre est important :
+// les partitions dont les préfixes de région sont les plus spécifiques (us-gov-, us-iso-, ...)
+// sont testées avant la partition commerciale "aws".
+var Partitions = []Partition{
+	{
+		ID:             "aws-us-gov",
+		Name:           "AWS GovCloud (US)",
+		DNSSuffix:      "amazonaws.com",
+		RegionPrefixes: []string{"us-gov-east-", "us-gov-west-"},
+	},
+	{
+		ID:             "aws-iso",
+		Name:           "AWS ISO (US)",
+		DNSSuffix:      "c2s.ic.gov",
+		RegionPrefixes: []string{"us-iso-east-", "us-iso-west-"},
+	},
+	{
+		ID:             "aws-iso-b",
+		Name:           "AWS ISOB (US)",
+		DNSSuffix:      "sc2s.sgov.gov",
+		RegionPrefixes: []string{"us-isob-east-"},
+	},
+	{
+		ID:             "aws-iso-e",
+		Name:           "AWS ISOE (Europe)",
+		DNSSuffix:      "cloud.adc-e.uk",
+		RegionPrefixes: []string{"eu-isoe-west-"},
+	},
+	{
+		ID:             "aws-iso-f",
+		Name:           "AWS ISOF",
+		DNSSuffix:      "csp.hci.ic.gov",
+		RegionPrefixes: []string{"us-isof-east-", "us-isof-south-"},
+	},
+	{
+		ID:             "aws-cn",
+		Name:           "AWS China",
+		DNSSuffix:      "amazonaws.com.cn",
+		RegionPrefixes: []string{"cn-north-", "cn-northwest-"},
+	},
+	{
+		ID:        "aws",
+		Name:      "AWS Standard",
+		DNSSuffix: "amazonaws.com",
+		RegionPrefixes: []string{
+			"af-south-",
+			"ap-east-",
+			"ap-northeast-",
+			"ap-south-",
+			"ap-southeast-",
+			"ca-central-",
+			"ca-west-",
+			"eu-central-",
+			"eu-north-",
+			"eu-south-",
+			"eu-west-",
+			"il-central-",
+			"me-central-",
+			"me-south-",
+			"mx-central-",
+			"sa-east-",
+			"us-east-",
+			"us-west-",
+		},
+	},
+}
+
+// regionSuffixRegex vérifie qu'une région se termine par un nombre (ex: eu-west-1).
+var regionSuffixRegex = regexp.MustCompile(`^[a-z-]+-\d+$`)
+
+// accountIDRegex vérifie qu'un identifiant de compte AWS contient exactement 12 chiffres.
+var accountIDRegex = regexp.MustCompile(`^\d{12}$`)
+
+// roleResourceRegex valide la partie ressource d'un ARN de rôle IAM (avec chemin optionnel).
+var roleResourceRegex = regexp.MustCompile(`^role/([a-zA-Z0-9+=,.@_-]+/)*[a-zA-Z0-9+=,.@_-]+$`)
+
+// roleNameRegex valide un nom de rôle IAM (sans chemin).
+var roleNameRegex = regexp.MustCompile(`^[a-zA-Z0-9+=,.@_-]{1,64}$`)
+
+// ByID retourne la partition correspondant à l'identifiant donné (ex: "aws-cn").
+func ByID(id string) (Partition, bool) {
+	for _, partition := range Partitions {
+		if partition.ID == id {
+			return partition, true
+		}
+	}
+	return Partition{}, false
+}
+
+// ForRegion retourne la partition à laquelle appartient une région AWS.
+// Le second résultat est false si la région n'est pas valide.
+func ForRegion(region string) (Partition, bool) {
+	if !regionSuffixRegex.MatchString(region) {
+		return Partition{}, false
+	}
+
+	for _, partition := range Partitions {
+		for _, prefix := range partition.RegionPrefixes {
+			if strings.HasPrefix(region, prefix) {
+				return partition, true
+			}
+		}
+	}
+	return Partition{}, false
+}
+
+// IsValidRegion vérifie si une région AWS est valide en testant si elle commence
+// par un préfixe connu d'une des partitions et se termine par un nombre.
+func IsValidRegion(region string) bool {
+	_, ok := ForRegion(region)
+	return ok
+}
+
+// ValidateARN vérifie qu'une chaîne est un ARN valide pour l'une des partitions connues.
+// Si service n'est pas vide, le namespace de service de l'ARN doit lui correspondre.
+// La région (si présente) doit appartenir à la partition de l'ARN, et l'identifiant
+// de compte (si présent) doit contenir 12 chiffres ou valoir "aws" (policies gérées par AWS).
+func ValidateARN(value, service string) error {
+	parsed, err := arn.Parse(value)
+	if err != nil {
+		return err
+	}
+
+	partition, ok := ByID(parsed.Partition)
+	if !ok {
+		return fmt.Errorf("invalid partition value (expected one of %s)", strings.Join(partitionIDs(), ", "))
+	}
+
+	if parsed.Service == "" || (service != "" && parsed.Service != service) {
+		if service != "" {
+			return fmt.Errorf("invalid service value (expected %q)", service)
+		}
+		return errors.New("invalid service value")
+	}
+
+	if parsed.Region != "" {
+		regionPartition, ok := ForRegion(parsed.Region)
+		if !ok || regionPartition.ID != partition.ID {
+			return fmt.Errorf("invalid region value (expected a region of the %s partition)", partition.ID)
+		}
+	}
+
+	if parsed.AccountID != "" && parsed.AccountID != "aws" && !accountIDRegex.MatchString(parsed.AccountID) {
+		return errors.New("invalid account ID value")
+	}
+
+	if parsed.Resource == "" {
+		return errors.New("invalid resource value")
+	}
+
+	return nil
+}
+
+// ValidateRoleARN vérifie qu'une chaîne est un ARN de rôle IAM valide
+// (arn:PARTITION:iam::ACCOUNT_ID:role/ROLE_NAME) pour l'une des partitions connues.
+func ValidateRoleARN(value string) error {
+	if err := ValidateARN(value, "iam"); err != nil {
+		return err
+	}
+
+	parsed, _ := arn.Parse(value)
+	if parsed.Region != "" {
+		return errors.New("invalid region value (IAM ARNs are global)")
+	}
+	if !accountIDRegex.MatchString(parsed.AccountID) {
+		return errors.New("invalid account ID value")
+	}
+	if !roleResourceRegex.MatchString(parsed.Resource) {
+		return errors.New("invalid role name value")
+	}
+
+	return nil
+}
+
+// ValidateRoleNameOrARN vérifie qu'une chaîne est soit un nom de rôle IAM, soit un ARN de rôle
+// IAM valide pour l'une des partitions connues. Les valeurs commençant par "arn:" sont validées
+// comme des ARN.
+func ValidateRoleNameOrARN(value string) error {
+	if strings.HasPrefix(value, "arn:") {
+		return ValidateRoleARN(value)
+	}
+	if !roleNameRegex.MatchString(value) {
+		return errors.New("a role name must be 1 to 64 characters long and contain only letters, digits and the characters +=,.@_-")
+	}
+
+	return nil
+}
+
+// partitionIDs retourne la liste des identifiants de partition connus.
+func partitionIDs() []string {
+	ids := make([]string, 0, len(Partitions))
+	for _, partition := range Partitions {
+		ids = append(ids, partition.ID)
+	}
+	return ids
+}
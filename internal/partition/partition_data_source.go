@@ -0,0 +1,109 @@
+package partition
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PartitionDataSource{}
+
+// NewPartitionDataSource crée et retourne une nouvelle instance du data source
+// PartitionDataSource. Cette fonction est utilisée par le provider pour enregistrer
+// le data source dans Terraform.
+func NewPartitionDataSource() datasource.DataSource {
+	return &PartitionDataSource{}
+}
+
+// PartitionDataSource expose la partition AWS correspondant à la région du provider.
+// Aucun appel à l'API AWS n'est effectué : la partition est déduite de la région
+// à partir de la table des partitions connues.
+type PartitionDataSource struct {
+	region string
+}
+
+// PartitionDataSourceModel définit le modèle de données pour le data source Partition.
+type PartitionDataSourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	Partition types.String `tfsdk:"partition"`
+	DNSSuffix types.String `tfsdk:"dns_suffix"`
+	Region    types.String `tfsdk:"region"`
+}
+
+// Metadata définit le nom du type de data source utilisé dans les configurations Terraform.
+// Ce nom est utilisé pour référencer ce data source dans les fichiers .tf.
+func (d *PartitionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "test_partition"
+}
+
+// Configure récupère la région résolue à partir de la configuration du provider.
+func (d *PartitionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	meta, diags := conns.FromProviderData(req.ProviderData, "partition data source")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
+		return
+	}
+
+	d.region = meta.Config.Region
+}
+
+// Schema définit la structure et la documentation du data source.
+// Cette méthode décrit les attributs disponibles, leurs types, et leur documentation Markdown
+// qui sera affichée dans la documentation Terraform.
+func (d *PartitionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `test_partition` data source returns the AWS partition (`aws`, `aws-cn`, `aws-us-gov`, ...) of the region configured in the provider, so that ARNs can be built without hardcoding the partition.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The partition identifier (same as partition).",
+			},
+			"partition": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The partition identifier, e.g. `aws`, `aws-cn` or `aws-us-gov`.",
+			},
+			"dns_suffix": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The DNS suffix of the partition, e.g. `amazonaws.com` or `amazonaws.com.cn`.",
+			},
+			"region": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The region configured in the provider.",
+			},
+		},
+	}
+}
+
+// Read déduit la partition de la région configurée dans le provider.
+func (d *PartitionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PartitionDataSourceModel
+
+	// Récupérer la configuration depuis la requête
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	partition, ok := ForRegion(d.region)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to determine AWS partition",
+			fmt.Sprintf("The provider region '%s' does not belong to any known AWS partition. Please set a valid region in the provider configuration or via the AWS_REGION environment variable.", d.region),
+		)
+		return
+	}
+
+	// Remplir le modèle avec la partition de la région
+	data.Id = types.StringValue(partition.ID)
+	data.Partition = types.StringValue(partition.ID)
+	data.DNSSuffix = types.StringValue(partition.DNSSuffix)
+	data.Region = types.StringValue(d.region)
+
+	// Sauvegarder les données dans l'état
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
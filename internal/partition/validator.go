@@ -0,0 +1,114 @@
+package partition
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// arnValidator valide qu'un attribut contient un ARN valide pour l'une des partitions AWS.
+type arnValidator struct {
+	service string
+}
+
+func (v arnValidator) Description(ctx context.Context) string {
+	if v.service == "" {
+		return "value must be a valid ARN"
+	}
+	return fmt.Sprintf("value must be a valid %s ARN", v.service)
+}
+
+func (v arnValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v arnValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if err := ValidateARN(value, v.service); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid ARN",
+			fmt.Sprintf("%q (%s) is an invalid ARN: %s", req.Path.String(), value, err),
+		)
+	}
+}
+
+// ARNValidator retourne un validateur qui vérifie que la valeur est un ARN valide
+// (toutes partitions confondues). Si service n'est pas vide, le namespace de service
+// de l'ARN doit lui correspondre (ex: "states", "iam", "sns").
+func ARNValidator(service string) validator.String {
+	return arnValidator{
+		service: service,
+	}
+}
+
+// roleValidator valide qu'un attribut contient un nom de rôle IAM ou un ARN de rôle IAM valide.
+type roleValidator struct{}
+
+func (v roleValidator) Description(ctx context.Context) string {
+	return "value must be an IAM role name or a valid IAM role ARN"
+}
+
+func (v roleValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v roleValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if err := ValidateRoleNameOrARN(value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid IAM role",
+			fmt.Sprintf("%q (%s) is an invalid IAM role name or ARN: %s", req.Path.String(), value, err),
+		)
+	}
+}
+
+// RoleValidator retourne un validateur qui vérifie que la valeur est un nom de rôle IAM ou
+// un ARN de rôle IAM valide (toutes partitions confondues), pour les attributs des API AWS
+// qui acceptent l'un ou l'autre.
+func RoleValidator() validator.String {
+	return roleValidator{}
+}
+
+// roleARNValidator valide qu'un attribut contient un ARN de rôle IAM valide.
+type roleARNValidator struct{}
+
+func (v roleARNValidator) Description(ctx context.Context) string {
+	return "value must be a valid IAM role ARN"
+}
+
+func (v roleARNValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v roleARNValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if err := ValidateRoleARN(value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid IAM role ARN",
+			fmt.Sprintf("%q (%s) is an invalid IAM role ARN: %s", req.Path.String(), value, err),
+		)
+	}
+}
+
+// RoleARNValidator retourne un validateur qui vérifie que la valeur est un ARN de rôle IAM
+// valide (toutes partitions confondues), pour les attributs des API AWS qui n'acceptent pas
+// de nom de rôle.
+func RoleARNValidator() validator.String {
+	return roleARNValidator{}
+}
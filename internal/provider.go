@@ -3,15 +3,22 @@ package internal
 import (
 	"context"
 	_ "embed"
+	"encoding/json"
 	"fmt"
-	"regexp"
+	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	awsservice "github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -20,10 +27,15 @@ import (
 
 	"github.com/jd-ucpa/terraform-provider-test/internal/cloudwatch"
 	"github.com/jd-ucpa/terraform-provider-test/internal/codebuild"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
 	"github.com/jd-ucpa/terraform-provider-test/internal/dynamodb"
 	"github.com/jd-ucpa/terraform-provider-test/internal/functions"
+	"github.com/jd-ucpa/terraform-provider-test/internal/partition"
+	"github.com/jd-ucpa/terraform-provider-test/internal/secretsmanager"
 	"github.com/jd-ucpa/terraform-provider-test/internal/sfn"
 	"github.com/jd-ucpa/terraform-provider-test/internal/ssm"
+	"github.com/jd-ucpa/terraform-provider-test/internal/sts"
+	"github.com/jd-ucpa/terraform-provider-test/internal/tags"
 )
 
 //go:embed VERSION
@@ -37,6 +49,8 @@ func Version() string {
 // Ensure TestProvider satisfies various provider interfaces.
 var _ provider.Provider = &TestProvider{}
 var _ provider.ProviderWithFunctions = &TestProvider{}
+var _ provider.ProviderWithEphemeralResources = &TestProvider{}
+var _ provider.ProviderWithActions = &TestProvider{}
 
 // TestProvider est le provider Terraform principal qui gère l'authentification AWS
 // et enregistre les ressources et data sources disponibles.
@@ -45,16 +59,57 @@ type TestProvider struct{}
 // TestProviderModel définit le modèle de configuration du provider.
 // Il contient les paramètres globaux comme la région, le profil et la configuration assume_role.
 type TestProviderModel struct {
-	Region    types.String `tfsdk:"region"`
-	Profile   types.String `tfsdk:"profile"`
-	AssumeRole *AssumeRoleModel `tfsdk:"assume_role"`
+	Region                    types.String                    `tfsdk:"region"`
+	Profile                   types.String                    `tfsdk:"profile"`
+	AccessKey                 types.String                    `tfsdk:"access_key"`
+	SecretKey                 types.String                    `tfsdk:"secret_key"`
+	Token                     types.String                    `tfsdk:"token"`
+	SharedConfigFiles         types.List                      `tfsdk:"shared_config_files"`
+	SharedCredentialsFiles    types.List                      `tfsdk:"shared_credentials_files"`
+	SkipCredentialsValidation types.Bool                      `tfsdk:"skip_credentials_validation"`
+	AllowedAccountIds         types.List                      `tfsdk:"allowed_account_ids"`
+	ForbiddenAccountIds       types.List                      `tfsdk:"forbidden_account_ids"`
+	Insecure                  types.Bool                      `tfsdk:"insecure"`
+	MaxRetries                types.Int64                     `tfsdk:"max_retries"`
+	RetryMode                 types.String                    `tfsdk:"retry_mode"`
+	RateLimits                types.Map                       `tfsdk:"rate_limits"`
+	HTTPProxy                 types.String                    `tfsdk:"http_proxy"`
+	CustomCABundle            types.String                    `tfsdk:"custom_ca_bundle"`
+	RequestTimeout            types.String                    `tfsdk:"request_timeout"`
+	AssumeRole                []AssumeRoleModel               `tfsdk:"assume_role"`
+	AssumeRoleWithWebIdentity *AssumeRoleWithWebIdentityModel `tfsdk:"assume_role_with_web_identity"`
+	Endpoints                 *EndpointsModel                 `tfsdk:"endpoints"`
+	DefaultTags               *DefaultTagsModel               `tfsdk:"default_tags"`
 }
 
 // AssumeRoleModel définit la configuration pour l'assumption de rôle AWS.
-// Il permet de spécifier un rôle IAM à assumer et un nom de session personnalisé.
+// Il permet de spécifier un rôle IAM à assumer ainsi que les paramètres de la session
+// (external ID, durée, policies de session, tags). Plusieurs blocs peuvent être chaînés.
 type AssumeRoleModel struct {
-	RoleArn     types.String `tfsdk:"role_arn"`
-	SessionName types.String `tfsdk:"session_name"`
+	RoleArn           types.String `tfsdk:"role_arn"`
+	SessionName       types.String `tfsdk:"session_name"`
+	ExternalId        types.String `tfsdk:"external_id"`
+	Duration          types.String `tfsdk:"duration"`
+	Policy            types.String `tfsdk:"policy"`
+	PolicyArns        types.List   `tfsdk:"policy_arns"`
+	Tags              types.Map    `tfsdk:"tags"`
+	TransitiveTagKeys types.List   `tfsdk:"transitive_tag_keys"`
+	SourceIdentity    types.String `tfsdk:"source_identity"`
+}
+
+// DefaultTagsModel définit les tags appliqués par défaut à toutes les ressources taggables.
+type DefaultTagsModel struct {
+	Tags types.Map `tfsdk:"tags"`
+}
+
+// AssumeRoleWithWebIdentityModel définit la configuration pour l'assumption de rôle AWS
+// à partir d'un jeton OIDC (GitHub Actions, GitLab CI, EKS, etc.).
+type AssumeRoleWithWebIdentityModel struct {
+	RoleArn              types.String `tfsdk:"role_arn"`
+	SessionName          types.String `tfsdk:"session_name"`
+	WebIdentityToken     types.String `tfsdk:"web_identity_token"`
+	WebIdentityTokenFile types.String `tfsdk:"web_identity_token_file"`
+	Duration             types.String `tfsdk:"duration"`
 }
 
 // Metadata définit le nom du provider utilisé dans les configurations Terraform.
@@ -81,21 +136,187 @@ func (p *TestProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 				MarkdownDescription: "The AWS profile to use for authentication. This can also be set via the `AWS_PROFILE` environment variable.",
 				Description:         "The AWS profile to use for authentication.",
 			},
+			"access_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "AWS access key. Must be set together with `secret_key`. Takes precedence over `profile`, environment variables and shared credentials files.",
+				Description:         "AWS access key.",
+			},
+			"secret_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "AWS secret key. Must be set together with `access_key`.",
+				Description:         "AWS secret key.",
+			},
+			"token": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Session token for temporary credentials. Only used together with `access_key` and `secret_key`.",
+				Description:         "Session token for temporary credentials.",
+			},
+			"shared_config_files": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "List of paths to AWS shared config files. If not set, defaults to `~/.aws/config`. Profiles using `credential_process`, SSO or `source_profile` are supported.",
+				Description:         "List of paths to AWS shared config files.",
+			},
+			"shared_credentials_files": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "List of paths to AWS shared credentials files. If not set, defaults to `~/.aws/credentials`.",
+				Description:         "List of paths to AWS shared credentials files.",
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Skip the credentials validation performed when the provider is configured. Useful when credentials are only available later or for AWS API implementations that do not require them. Defaults to `false`.",
+				Description:         "Skip the credentials validation performed when the provider is configured.",
+			},
+			"allowed_account_ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "List of allowed AWS account IDs to prevent you from mistakenly using an incorrect one. The account is resolved with STS `GetCallerIdentity` when the provider is configured. Conflicts with `forbidden_account_ids`.",
+				Description:         "List of allowed AWS account IDs. Conflicts with forbidden_account_ids.",
+			},
+			"forbidden_account_ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "List of forbidden AWS account IDs to prevent you from mistakenly using the wrong one. The account is resolved with STS `GetCallerIdentity` when the provider is configured. Conflicts with `allowed_account_ids`.",
+				Description:         "List of forbidden AWS account IDs. Conflicts with allowed_account_ids.",
+			},
+			"insecure": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Explicitly allow the provider to perform \"insecure\" SSL requests. If omitted, defaults to `false`. Useful with local AWS emulators using self-signed certificates.",
+				Description:         "Explicitly allow the provider to perform insecure SSL requests.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The maximum number of times a failed AWS API request is retried by the SDK (throttling, transient network errors, 5xx responses). Defaults to the SDK value (2 retries), or to `AWS_MAX_ATTEMPTS` minus one when set.",
+				Description:         "The maximum number of times a failed AWS API request is retried.",
+			},
+			"retry_mode": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Specifies how retries are attempted. Valid values are `standard` and `adaptive`. The `adaptive` mode additionally slows down requests on the client side when AWS returns throttling errors. This can also be set via the `AWS_RETRY_MODE` environment variable.",
+				Description:         "Specifies how retries are attempted. Valid values are standard and adaptive.",
+			},
+			"rate_limits": schema.MapAttribute{
+				ElementType:         types.Float64Type,
+				Optional:            true,
+				MarkdownDescription: "Client-side rate limit, in requests per second, applied to each AWS API request of a service. Keys are service names: `cloudwatch`, `codebuild`, `dynamodb`, `secretsmanager`, `sfn`, `ssm`, `sts`. Requests over the limit wait for their turn instead of being throttled by AWS, e.g. `{ ssm = 5, cloudwatch = 2 }`.",
+				Description:         "Client-side rate limit, in requests per second, for each AWS service.",
+			},
+			"http_proxy": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "URL of a proxy to use for all HTTP(S) requests to AWS APIs, e.g. `http://proxy.example.com:3128`. When not set, the `HTTP_PROXY`, `HTTPS_PROXY` and `NO_PROXY` environment variables are used.",
+				Description:         "URL of a proxy to use for HTTP(S) requests to AWS APIs.",
+			},
+			"custom_ca_bundle": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path to a file containing custom root and intermediate certificates in PEM format, e.g. for a TLS-inspecting proxy. This can also be set via the `AWS_CA_BUNDLE` environment variable.",
+				Description:         "Path to a PEM file containing custom root and intermediate certificates.",
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Timeout of each HTTP request (attempt) to AWS APIs, as a Go duration, e.g. `30s` or `2m`. Retried attempts get a new timeout. Defaults to no timeout.",
+				Description:         "Timeout of each HTTP request to AWS APIs, e.g. 30s.",
+			},
 		},
 		Blocks: map[string]schema.Block{
-			"assume_role": schema.SingleNestedBlock{
-				MarkdownDescription: "Configuration for assuming an IAM role.",
+			"assume_role": schema.ListNestedBlock{
+				MarkdownDescription: "Configuration for assuming an IAM role. Multiple blocks can be specified to chain role assumptions: each role is assumed with the credentials obtained from the previous one.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"role_arn": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The ARN of the role to assume. This can also be set via the `TF_VAR_assume_role_role_arn` environment variable.",
+							Description:         "The ARN of the role to assume.",
+						},
+						"session_name": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The session name to use when assuming the role. If not provided, defaults to `terraform-provider-test`.",
+							Description:         "The session name to use when assuming the role.",
+						},
+						"external_id": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "A unique identifier that might be required when you assume a role in another account.",
+							Description:         "A unique identifier that might be required when you assume a role in another account.",
+						},
+						"duration": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The duration, between 15 minutes and 12 hours, of the role session. Valid time units are `ns`, `us` (or `µs`), `ms`, `s`, `h`, or `m` (e.g. `1h30m`). Defaults to 15 minutes.",
+							Description:         "The duration, between 15 minutes and 12 hours, of the role session.",
+						},
+						"policy": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "IAM policy JSON describing further restricting permissions for the IAM role being assumed.",
+							Description:         "IAM policy JSON describing further restricting permissions for the IAM role being assumed.",
+						},
+						"policy_arns": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Optional:            true,
+							MarkdownDescription: "List of Amazon Resource Names (ARNs) of IAM managed policies describing further restricting permissions for the IAM role being assumed.",
+							Description:         "ARNs of IAM managed policies further restricting permissions for the IAM role being assumed.",
+						},
+						"tags": schema.MapAttribute{
+							ElementType:         types.StringType,
+							Optional:            true,
+							MarkdownDescription: "Map of assume role session tags.",
+							Description:         "Map of assume role session tags.",
+						},
+						"transitive_tag_keys": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Optional:            true,
+							MarkdownDescription: "List of assume role session tag keys to pass to any subsequent sessions in the role chain.",
+							Description:         "List of assume role session tag keys to pass to any subsequent sessions.",
+						},
+						"source_identity": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Source identity specified by the principal assuming the role.",
+							Description:         "Source identity specified by the principal assuming the role.",
+						},
+					},
+				},
+			},
+			"assume_role_with_web_identity": schema.SingleNestedBlock{
+				MarkdownDescription: "Configuration for assuming an IAM role using a web identity (OIDC) token. The resulting credentials are used as the base credentials for any `assume_role` block.",
 				Attributes: map[string]schema.Attribute{
 					"role_arn": schema.StringAttribute{
 						Optional:            true,
-						MarkdownDescription: "The ARN of the role to assume. This can also be set via the `TF_VAR_assume_role_role_arn` environment variable.",
+						MarkdownDescription: "The ARN of the role to assume. This can also be set via the `AWS_ROLE_ARN` environment variable.",
 						Description:         "The ARN of the role to assume.",
 					},
 					"session_name": schema.StringAttribute{
 						Optional:            true,
-						MarkdownDescription: "The session name to use when assuming the role. If not provided, defaults to `terraform-provider-test`.",
+						MarkdownDescription: "The session name to use when assuming the role. If not provided, defaults to the `AWS_ROLE_SESSION_NAME` environment variable or `terraform-provider-test`.",
 						Description:         "The session name to use when assuming the role.",
 					},
+					"web_identity_token": schema.StringAttribute{
+						Optional:            true,
+						Sensitive:           true,
+						MarkdownDescription: "The value of a web identity token from an OpenID Connect (OIDC) or OAuth provider. One of `web_identity_token` or `web_identity_token_file` is required.",
+						Description:         "The value of a web identity token from an OIDC or OAuth provider.",
+					},
+					"web_identity_token_file": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "File containing a web identity token from an OpenID Connect (OIDC) or OAuth provider. This can also be set via the `AWS_WEB_IDENTITY_TOKEN_FILE` environment variable. One of `web_identity_token` or `web_identity_token_file` is required.",
+						Description:         "File containing a web identity token from an OIDC or OAuth provider.",
+					},
+					"duration": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "The duration, between 15 minutes and 12 hours, of the role session. Valid time units are `ns`, `us` (or `µs`), `ms`, `s`, `h`, or `m` (e.g. `1h30m`). Defaults to 15 minutes.",
+						Description:         "The duration, between 15 minutes and 12 hours, of the role session.",
+					},
+				},
+			},
+			"endpoints": endpointsSchemaBlock(),
+			"default_tags": schema.SingleNestedBlock{
+				MarkdownDescription: "Configuration block with tags applied to all taggable resources (SSM activations and the Secrets Manager secrets they manage). Tags defined on a resource override default tags with the same key; the merged result is exposed in the `tags_all` attribute of each resource.",
+				Attributes: map[string]schema.Attribute{
+					"tags": schema.MapAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "A map of tags to apply to all taggable resources. Keys starting with `aws:` are reserved and not allowed.",
+						Description:         "A map of tags to apply to all taggable resources.",
+					},
 				},
 			},
 		},
@@ -107,18 +328,64 @@ func (p *TestProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 // les clients AWS pour les ressources et data sources.
 func (p *TestProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var config TestProviderModel
-	
+
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Configuration AWS de base - utilise les credentials par défaut du système
-	var cfg aws.Config
-	var err error
+	// Valider la région avant de charger la configuration
+	if !config.Region.IsNull() {
+		region := config.Region.ValueString()
+		if !isValidRegion(region) {
+			resp.Diagnostics.AddError(
+				"Invalid AWS region configuration",
+				fmt.Sprintf("invalid AWS Region: %s", region),
+			)
+			return
+		}
+	}
+
+	// allowed_account_ids et forbidden_account_ids sont mutuellement exclusifs
+	if !config.AllowedAccountIds.IsNull() && !config.ForbiddenAccountIds.IsNull() {
+		resp.Diagnostics.AddError(
+			"Conflicting account restrictions",
+			"Cannot specify both allowed_account_ids and forbidden_account_ids. Please choose one of them.",
+		)
+		return
+	}
 
-	// Charger la configuration AWS par défaut (utilise les credentials AWS standard)
-	cfg, err = awsconfig.LoadDefaultConfig(ctx)
+	// Tags par défaut appliqués à toutes les ressources taggables
+	defaultTags := map[string]string{}
+	if config.DefaultTags != nil && !config.DefaultTags.Tags.IsNull() {
+		resp.Diagnostics.Append(config.DefaultTags.Tags.ElementsAs(ctx, &defaultTags, false)...)
+		resp.Diagnostics.Append(tags.Validate("default_tags.tags", defaultTags)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Endpoints personnalisés, appliqués à chaque client AWS créé par le provider
+	endpoints, diags := config.Endpoints.serviceEndpoints()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	stsEndpoint := func(o *awsservice.Options) {
+		if endpoint, ok := endpoints["sts"]; ok {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	}
+
+	// Construire les options de chargement de la configuration AWS
+	loadOptions, diags := awsLoadOptions(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Charger la configuration AWS en une seule fois afin de respecter l'ordre de précédence
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, loadOptions...)
 	if err != nil {
 		// Vérifier si l'erreur est liée à un profil inexistant
 		if strings.Contains(err.Error(), "failed to get shared config profile") {
@@ -135,90 +402,119 @@ func (p *TestProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
-	// Appliquer la région si spécifiée
-	if !config.Region.IsNull() {
-		region := config.Region.ValueString()
-		
-		// Valider la région
-		if !isValidRegion(region) {
+	// Vérifier que des credentials valides ont été trouvés, sauf si la validation est désactivée.
+	// Avec assume_role_with_web_identity, aucune credential de base n'est nécessaire.
+	if !config.SkipCredentialsValidation.ValueBool() && config.AssumeRoleWithWebIdentity == nil {
+		if _, err := cfg.Credentials.Retrieve(ctx); err != nil {
 			resp.Diagnostics.AddError(
-				"Invalid AWS region configuration",
-				fmt.Sprintf("invalid AWS Region: %s", region),
+				"No valid credential sources found",
+				fmt.Sprintf("Error retrieving AWS credentials: %s. Please configure access_key/secret_key, a profile, environment variables, or an IAM role, or set skip_credentials_validation = true.", err),
 			)
 			return
 		}
-		
-		cfg.Region = region
 	}
 
-	// Appliquer le profil si spécifié
-	if !config.Profile.IsNull() {
-		profile := config.Profile.ValueString()
-		
-		// Charger la configuration AWS avec le profil spécifié
-		cfg, err = awsconfig.LoadDefaultConfig(ctx, awsconfig.WithSharedConfigProfile(profile))
-		if err != nil {
+	// Configuration de l'assume role avec web identity si spécifiée. Les credentials obtenus
+	// servent de credentials de base pour les éventuels blocs assume_role.
+	if config.AssumeRoleWithWebIdentity != nil {
+		webIdentity := config.AssumeRoleWithWebIdentity
+
+		roleArn := webIdentity.RoleArn.ValueString()
+		if webIdentity.RoleArn.IsNull() || roleArn == "" {
+			roleArn = os.Getenv("AWS_ROLE_ARN")
+		}
+
+		// Valider le format de l'ARN et sa partition
+		if err := validateRoleARN(roleArn, cfg.Region); err != nil {
 			resp.Diagnostics.AddError(
-				"Failed to load AWS profile",
-				fmt.Sprintf("Unable to load AWS profile '%s': %s. Please verify that the profile exists in your AWS credentials file (~/.aws/credentials) and is properly configured.", profile, err.Error()),
+				"Invalid IAM role ARN configuration",
+				fmt.Sprintf(`"assume_role_with_web_identity.role_arn" (%s) is an invalid ARN: %s`, roleArn, err),
 			)
 			return
 		}
-		
-		// Réappliquer la région si elle était spécifiée (car LoadDefaultConfig peut la réinitialiser)
-		if !config.Region.IsNull() {
-			cfg.Region = config.Region.ValueString()
+
+		// Préparer la source du jeton et les options de session
+		tokenRetriever, webIdentityOptions, diags := buildWebIdentityOptions(webIdentity)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Créer le provider web identity (l'appel STS AssumeRoleWithWebIdentity n'est pas signé)
+		stsClient := awsservice.NewFromConfig(cfg, stsEndpoint)
+		cfg.Credentials = aws.NewCredentialsCache(stscreds.NewWebIdentityRoleProvider(stsClient, roleArn, tokenRetriever, webIdentityOptions))
+
+		// Tester l'assume role pour s'assurer qu'il fonctionne
+		_, err = cfg.Credentials.Retrieve(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to assume IAM role with web identity",
+				fmt.Sprintf("Error assuming IAM role '%s' with web identity: %s. Please verify that the role exists, that its trust policy allows your OIDC provider and token claims (audience, subject), and that the token has not expired.", roleArn, err),
+			)
+			return
 		}
 	}
 
-	// Configuration de l'assume role si spécifiée
-	if config.AssumeRole != nil && !config.AssumeRole.RoleArn.IsNull() {
-		roleArn := config.AssumeRole.RoleArn.ValueString()
-		
-		// Valider le format de l'ARN
-		if !isValidRoleARN(roleArn) {
+	// Configuration de l'assume role si spécifiée. Les blocs sont assumés dans l'ordre :
+	// chaque rôle est assumé avec les credentials obtenus à l'étape précédente.
+	for i, assumeRole := range config.AssumeRole {
+		if assumeRole.RoleArn.IsNull() {
+			continue
+		}
+		roleArn := assumeRole.RoleArn.ValueString()
+
+		// Valider le format de l'ARN et sa partition
+		if err := validateRoleARN(roleArn, cfg.Region); err != nil {
 			resp.Diagnostics.AddError(
 				"Invalid IAM role ARN configuration",
-				fmt.Sprintf(`"assume_role.0.role_arn" (%s) is an invalid ARN: invalid account ID value`, roleArn),
+				fmt.Sprintf(`"assume_role.%d.role_arn" (%s) is an invalid ARN: %s`, i, roleArn, err),
 			)
 			return
 		}
-		
-		stsClient := awsservice.NewFromConfig(cfg)
-		
+
 		// Préparer les options pour l'assume role
-		assumeRoleOptions := []func(*stscreds.AssumeRoleOptions){
-			func(options *stscreds.AssumeRoleOptions) {
-				options.RoleARN = roleArn
-			},
-		}
-		
-		// Ajouter le session name (par défaut ou spécifié)
-		sessionName := "terraform-provider-test"
-		if !config.AssumeRole.SessionName.IsNull() {
-			sessionName = config.AssumeRole.SessionName.ValueString()
+		assumeRoleOptions, diags := buildAssumeRoleOptions(ctx, i, assumeRole)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
-		assumeRoleOptions = append(assumeRoleOptions, func(options *stscreds.AssumeRoleOptions) {
-			options.RoleSessionName = sessionName
-		})
-		
-		// Créer le provider d'assume role
-		cfg.Credentials = stscreds.NewAssumeRoleProvider(stsClient, config.AssumeRole.RoleArn.ValueString(), assumeRoleOptions...)
-		
+
+		// Créer le provider d'assume role à partir des credentials courants
+		stsClient := awsservice.NewFromConfig(cfg, stsEndpoint)
+		cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(stsClient, roleArn, assumeRoleOptions))
+
 		// Tester l'assume role pour s'assurer qu'il fonctionne
 		_, err = cfg.Credentials.Retrieve(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to assume IAM role",
-				fmt.Sprintf("Error assuming IAM role '%s': %s. Please verify that the role exists, you have permission to assume it, and that your current credentials are valid.", roleArn, err),
+				fmt.Sprintf("Error assuming IAM role '%s' (assume_role.%d): %s. Please verify that the role exists, you have permission to assume it, and that your current credentials are valid.", roleArn, i, err),
 			)
 			return
 		}
 	}
 
-	// Partager la configuration AWS avec les ressources
-	resp.DataSourceData = cfg
-	resp.ResourceData = cfg
+	// Métadonnées partagées avec les ressources et data sources : configuration AWS,
+	// tags par défaut, endpoints personnalisés et cache de clients AWS
+	meta := &conns.ProviderMeta{
+		Config:      cfg,
+		DefaultTags: defaultTags,
+		Endpoints:   endpoints,
+	}
+
+	// Vérifier que le compte AWS résolu est autorisé avant d'exposer les credentials aux ressources
+	if !config.AllowedAccountIds.IsNull() || !config.ForbiddenAccountIds.IsNull() {
+		resp.Diagnostics.Append(validateAccountID(ctx, meta, config)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Partager les métadonnées du provider avec les ressources, data sources et actions
+	resp.DataSourceData = meta
+	resp.ResourceData = meta
+	resp.EphemeralResourceData = meta
+	resp.ActionData = meta
 }
 
 // Resources enregistre toutes les ressources disponibles dans ce provider.
@@ -234,6 +530,25 @@ func (p *TestProvider) Resources(ctx context.Context) []func() resource.Resource
 	}
 }
 
+// EphemeralResources enregistre toutes les ressources éphémères disponibles dans ce provider.
+// Les valeurs des ressources éphémères ne sont jamais enregistrées dans le plan ni dans l'état.
+func (p *TestProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		ssm.NewActivationEphemeralResource,
+		secretsmanager.NewSecretValueEphemeralResource,
+	}
+}
+
+// Actions enregistre toutes les actions disponibles dans ce provider.
+// Les actions ne sont jamais enregistrées dans l'état et nécessitent Terraform 1.14 ou plus.
+func (p *TestProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		codebuild.NewStartBuildAction,
+		sfn.NewStartSyncExecutionAction,
+		ssm.NewSendCommandAction,
+	}
+}
+
 // DataSources enregistre tous les data sources disponibles dans ce provider.
 // Cette méthode retourne une liste de constructeurs de data sources qui seront
 // disponibles dans les configurations Terraform.
@@ -244,8 +559,10 @@ func (p *TestProvider) DataSources(ctx context.Context) []func() datasource.Data
 		dynamodb.NewScanDataSource,
 		functions.NewTimestampDataSource,
 		functions.NewJSONPrettyDataSource,
+		partition.NewPartitionDataSource,
 		ssm.NewActivationDataSource,
 		ssm.NewActivationsDataSource,
+		sts.NewCallerIdentityDataSource,
 	}
 }
 
@@ -254,7 +571,11 @@ func (p *TestProvider) DataSources(ctx context.Context) []func() datasource.Data
 // disponibles dans les configurations Terraform.
 func (p *TestProvider) Functions(ctx context.Context) []func() function.Function {
 	return []func() function.Function{
-		// No functions currently available
+		functions.NewTimestampFunction,
+		functions.NewJSONPrettyFunction,
+		functions.NewJSONMinifyFunction,
+		functions.NewARNParseFunction,
+		functions.NewARNBuildFunction,
 	}
 }
 
@@ -264,57 +585,228 @@ func Provider() provider.Provider {
 	return &TestProvider{}
 }
 
-// validRegionPrefixes contient la liste des préfixes de régions AWS valides.
-// Une région AWS valide commence par un de ces préfixes et se termine par un nombre.
-var validRegionPrefixes = []string{
-	"af-south-",
-	"ap-east-",
-	"ap-northeast-",
-	"ap-south-",
-	"ap-southeast-",
-	"ca-central-",
-	"ca-west-",
-	"cn-north-",
-	"cn-northwest-",
-	"eu-central-",
-	"eu-north-",
-	"eu-south-",
-	"eu-west-",
-	"il-central-",
-	"me-central-",
-	"me-south-",
-	"mx-central-",
-	"sa-east-",
-	"us-east-",
-	"us-gov-east-",
-	"us-gov-west-",
-	"us-west-",
+// isValidRegion vérifie si une région AWS est valide, c'est-à-dire si elle appartient
+// à l'une des partitions connues (aws, aws-cn, aws-us-gov, partitions isolées).
+// Cette fonction est utilisée pour valider la configuration du provider.
+func isValidRegion(region string) bool {
+	return partition.IsValidRegion(region)
 }
 
-// isValidRegion vérifie si une région AWS est valide en testant si elle commence
-// par un préfixe valide et se termine par un nombre. Cette fonction est utilisée
-// pour valider la configuration du provider.
-func isValidRegion(region string) bool {
-	// Vérifier si la région se termine par un nombre
-	if !regexp.MustCompile(`\d+$`).MatchString(region) {
-		return false
-	}
-	
-	// Vérifier si la région commence par un préfixe valide
-	for _, prefix := range validRegionPrefixes {
-		if strings.HasPrefix(strings.ToLower(region), prefix) {
-			return true
+// validateRoleARN vérifie si un ARN de rôle IAM est valide pour l'une des partitions
+// connues (arn:PARTITION:iam::ACCOUNT_ID:role/ROLE_NAME). Si la région est connue,
+// la partition de l'ARN doit correspondre à celle de la région, afin de détecter
+// par exemple un rôle "arn:aws:iam::..." utilisé avec une région GovCloud.
+func validateRoleARN(roleArn, region string) error {
+	if err := partition.ValidateRoleARN(roleArn); err != nil {
+		return err
+	}
+
+	if regionPartition, ok := partition.ForRegion(region); ok {
+		if arnPartition := strings.SplitN(roleArn, ":", 3)[1]; arnPartition != regionPartition.ID {
+			return fmt.Errorf("invalid partition value (region %s belongs to the %s partition)", region, regionPartition.ID)
 		}
 	}
-	return false
+
+	return nil
 }
 
-// isValidRoleARN vérifie si un ARN de rôle IAM est valide en utilisant une expression
-// régulière. Cette fonction valide le format arn:aws:iam::ACCOUNT_ID:role/ROLE_NAME
-// et s'assure que l'ACCOUNT_ID contient exactement 12 chiffres.
-func isValidRoleARN(roleArn string) bool {
-	// Regex pour valider un ARN de rôle IAM
-	// Format: arn:aws:iam::ACCOUNT_ID:role/ROLE_NAME
-	arnRegex := regexp.MustCompile(`^arn:aws:iam::(\d{12}):role/[a-zA-Z0-9+=,.@_-]+$`)
-	return arnRegex.MatchString(roleArn)
+// buildAssumeRoleOptions convertit un bloc assume_role en options pour stscreds.
+// La durée, la policy JSON et les listes de policies/tags sont validées ici afin
+// que les erreurs de configuration soient signalées avant l'appel à STS.
+func buildAssumeRoleOptions(ctx context.Context, index int, assumeRole AssumeRoleModel) (func(*stscreds.AssumeRoleOptions), diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	// Session name par défaut ou spécifié
+	sessionName := "terraform-provider-test"
+	if !assumeRole.SessionName.IsNull() && assumeRole.SessionName.ValueString() != "" {
+		sessionName = assumeRole.SessionName.ValueString()
+	}
+
+	duration, diags := parseSessionDuration(fmt.Sprintf("assume_role.%d.duration", index), assumeRole.Duration)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return nil, diagnostics
+	}
+
+	if !assumeRole.Policy.IsNull() && !json.Valid([]byte(assumeRole.Policy.ValueString())) {
+		diagnostics.AddError(
+			"Invalid assume_role policy",
+			fmt.Sprintf(`"assume_role.%d.policy" contains an invalid JSON policy document. Please verify the policy syntax.`, index),
+		)
+		return nil, diagnostics
+	}
+
+	var policyArns []string
+	if !assumeRole.PolicyArns.IsNull() {
+		diagnostics.Append(assumeRole.PolicyArns.ElementsAs(ctx, &policyArns, false)...)
+	}
+
+	var sessionTags map[string]string
+	if !assumeRole.Tags.IsNull() {
+		diagnostics.Append(assumeRole.Tags.ElementsAs(ctx, &sessionTags, false)...)
+	}
+
+	var transitiveTagKeys []string
+	if !assumeRole.TransitiveTagKeys.IsNull() {
+		diagnostics.Append(assumeRole.TransitiveTagKeys.ElementsAs(ctx, &transitiveTagKeys, false)...)
+	}
+
+	if diagnostics.HasError() {
+		return nil, diagnostics
+	}
+
+	return func(options *stscreds.AssumeRoleOptions) {
+		options.RoleSessionName = sessionName
+		if duration > 0 {
+			options.Duration = duration
+		}
+		if !assumeRole.ExternalId.IsNull() && assumeRole.ExternalId.ValueString() != "" {
+			options.ExternalID = aws.String(assumeRole.ExternalId.ValueString())
+		}
+		if !assumeRole.Policy.IsNull() && assumeRole.Policy.ValueString() != "" {
+			options.Policy = aws.String(assumeRole.Policy.ValueString())
+		}
+		for _, policyArn := range policyArns {
+			options.PolicyARNs = append(options.PolicyARNs, ststypes.PolicyDescriptorType{Arn: aws.String(policyArn)})
+		}
+		for key, value := range sessionTags {
+			options.Tags = append(options.Tags, ststypes.Tag{Key: aws.String(key), Value: aws.String(value)})
+		}
+		options.TransitiveTagKeys = transitiveTagKeys
+		if !assumeRole.SourceIdentity.IsNull() && assumeRole.SourceIdentity.ValueString() != "" {
+			options.SourceIdentity = aws.String(assumeRole.SourceIdentity.ValueString())
+		}
+	}, diagnostics
+}
+
+// validateAccountID récupère l'identifiant du compte AWS via STS GetCallerIdentity
+// avec les credentials finaux (après assume role) et vérifie qu'il respecte
+// allowed_account_ids ou forbidden_account_ids.
+func validateAccountID(ctx context.Context, meta *conns.ProviderMeta, config TestProviderModel) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	var allowed, forbidden []string
+	if !config.AllowedAccountIds.IsNull() {
+		diagnostics.Append(config.AllowedAccountIds.ElementsAs(ctx, &allowed, false)...)
+	}
+	if !config.ForbiddenAccountIds.IsNull() {
+		diagnostics.Append(config.ForbiddenAccountIds.ElementsAs(ctx, &forbidden, false)...)
+	}
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	accountID, err := meta.AccountID(ctx)
+	if err != nil {
+		diagnostics.AddError(
+			"Unable to verify AWS account ID",
+			fmt.Sprintf("Error calling AWS STS GetCallerIdentity API: %s. The account ID must be resolved when allowed_account_ids or forbidden_account_ids is set. Please verify your AWS credentials and that sts:GetCallerIdentity is reachable.", err),
+		)
+		return diagnostics
+	}
+
+	if len(allowed) > 0 && !slices.Contains(allowed, accountID) {
+		diagnostics.AddError(
+			"AWS account ID not allowed",
+			fmt.Sprintf("AWS account ID not allowed: %s. The provider is restricted to the accounts %s by allowed_account_ids. Please verify your credentials, profile and assume_role configuration.", accountID, strings.Join(allowed, ", ")),
+		)
+		return diagnostics
+	}
+
+	if slices.Contains(forbidden, accountID) {
+		diagnostics.AddError(
+			"AWS account ID forbidden",
+			fmt.Sprintf("AWS account ID forbidden: %s. This account is listed in forbidden_account_ids. Please verify your credentials, profile and assume_role configuration.", accountID),
+		)
+		return diagnostics
+	}
+
+	return diagnostics
+}
+
+// buildWebIdentityOptions prépare la source du jeton OIDC et les options de session
+// pour stscreds.NewWebIdentityRoleProvider. Le jeton peut être fourni directement,
+// via un fichier ou via la variable d'environnement AWS_WEB_IDENTITY_TOKEN_FILE.
+func buildWebIdentityOptions(webIdentity *AssumeRoleWithWebIdentityModel) (stscreds.IdentityTokenRetriever, func(*stscreds.WebIdentityRoleOptions), diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	token := webIdentity.WebIdentityToken.ValueString()
+	tokenFile := webIdentity.WebIdentityTokenFile.ValueString()
+
+	if token != "" && tokenFile != "" {
+		diagnostics.AddError(
+			"Conflicting web identity token configuration",
+			"Cannot specify both web_identity_token and web_identity_token_file. Use either the token value or the token file, not both.",
+		)
+		return nil, nil, diagnostics
+	}
+
+	var tokenRetriever stscreds.IdentityTokenRetriever
+	switch {
+	case token != "":
+		tokenRetriever = staticIdentityToken(token)
+	case tokenFile != "":
+		tokenRetriever = stscreds.IdentityTokenFile(tokenFile)
+	case os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE") != "":
+		tokenRetriever = stscreds.IdentityTokenFile(os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"))
+	default:
+		diagnostics.AddError(
+			"Missing web identity token",
+			"One of web_identity_token or web_identity_token_file must be specified (or the AWS_WEB_IDENTITY_TOKEN_FILE environment variable set) when using assume_role_with_web_identity.",
+		)
+		return nil, nil, diagnostics
+	}
+
+	duration, diags := parseSessionDuration("assume_role_with_web_identity.duration", webIdentity.Duration)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return nil, nil, diagnostics
+	}
+
+	// Session name spécifié, puis AWS_ROLE_SESSION_NAME, puis valeur par défaut
+	sessionName := webIdentity.SessionName.ValueString()
+	if sessionName == "" {
+		sessionName = os.Getenv("AWS_ROLE_SESSION_NAME")
+	}
+	if sessionName == "" {
+		sessionName = "terraform-provider-test"
+	}
+
+	return tokenRetriever, func(options *stscreds.WebIdentityRoleOptions) {
+		options.RoleSessionName = sessionName
+		if duration > 0 {
+			options.Duration = duration
+		}
+	}, diagnostics
+}
+
+// staticIdentityToken implémente stscreds.IdentityTokenRetriever pour un jeton
+// fourni directement dans la configuration du provider.
+type staticIdentityToken string
+
+// GetIdentityToken retourne le jeton OIDC configuré.
+func (t staticIdentityToken) GetIdentityToken() ([]byte, error) {
+	return []byte(t), nil
+}
+
+// parseSessionDuration convertit la durée d'une session STS et vérifie qu'elle est
+// comprise entre 15 minutes et 12 heures. Une valeur absente retourne 0, ce qui laisse
+// STS appliquer sa durée par défaut.
+func parseSessionDuration(attribute string, value types.String) (time.Duration, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return 0, diagnostics
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil || duration < 15*time.Minute || duration > 12*time.Hour {
+		diagnostics.AddError(
+			"Invalid session duration",
+			fmt.Sprintf(`"%s" (%s) must be a valid duration between 15m and 12h (e.g. "1h", "90m").`, attribute, value.ValueString()),
+		)
+		return 0, diagnostics
+	}
+
+	return duration, diagnostics
 }
@@ -0,0 +1,98 @@
+package region
+
+import (
+	"context"
+	"fmt"
+
+	actionschema "github.com/hashicorp/terraform-plugin-framework/action/schema"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	ephemeralschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/jd-ucpa/terraform-provider-test/internal/partition"
+)
+
+// description est la documentation commune de l'attribut region.
+const description = "The AWS region in which the API calls of this %s are made, e.g. `us-east-1`. Defaults to the region of the provider. The provider credentials are reused, so a single provider can target several regions."
+
+// regionValidator valide qu'un attribut contient une région AWS connue.
+type regionValidator struct{}
+
+func (v regionValidator) Description(ctx context.Context) string {
+	return "value must be a valid AWS region"
+}
+
+func (v regionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if !partition.IsValidRegion(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid AWS region",
+			fmt.Sprintf("invalid AWS Region: %s", value),
+		)
+	}
+}
+
+// Validator retourne un validateur qui vérifie que la valeur est une région AWS connue,
+// avec les mêmes règles que la région du provider.
+func Validator() validator.String {
+	return regionValidator{}
+}
+
+// ResourceAttribute retourne l'attribut optionnel `region` des ressources.
+// Un changement de région entraîne la recréation de la ressource.
+func ResourceAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: fmt.Sprintf(description, "resource"),
+		Validators: []validator.String{
+			Validator(),
+		},
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// DataSourceAttribute retourne l'attribut optionnel `region` des data sources.
+func DataSourceAttribute() datasourceschema.StringAttribute {
+	return datasourceschema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: fmt.Sprintf(description, "data source"),
+		Validators: []validator.String{
+			Validator(),
+		},
+	}
+}
+
+// EphemeralResourceAttribute retourne l'attribut optionnel `region` des ressources éphémères.
+func EphemeralResourceAttribute() ephemeralschema.StringAttribute {
+	return ephemeralschema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: fmt.Sprintf(description, "ephemeral resource"),
+		Validators: []validator.String{
+			Validator(),
+		},
+	}
+}
+
+// ActionAttribute retourne l'attribut optionnel `region` des actions.
+func ActionAttribute() actionschema.StringAttribute {
+	return actionschema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: fmt.Sprintf(description, "action"),
+		Validators: []validator.String{
+			Validator(),
+		},
+	}
+}
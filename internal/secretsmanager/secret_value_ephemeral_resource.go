@@ -0,0 +1,257 @@
+package secretsmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+	"github.com/jd-ucpa/terraform-provider-test/internal/region"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &SecretValueEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &SecretValueEphemeralResource{}
+
+// NewSecretValueEphemeralResource crée et retourne une nouvelle instance de la ressource éphémère
+// SecretValueEphemeralResource. Cette fonction est utilisée par le provider pour enregistrer
+// la ressource éphémère dans Terraform.
+func NewSecretValueEphemeralResource() ephemeral.EphemeralResource {
+	return &SecretValueEphemeralResource{}
+}
+
+// SecretValueEphemeralResource lit la valeur d'un secret Secrets Manager ou d'un paramètre
+// SSM Parameter Store (SecureString déchiffré) pour l'exécution en cours, sans l'enregistrer
+// dans le plan ni dans l'état. Les clés d'un secret JSON peuvent être extraites individuellement.
+type SecretValueEphemeralResource struct {
+	meta *conns.ProviderMeta
+}
+
+// SecretValueEphemeralResourceModel définit le modèle de données pour la ressource éphémère SecretValue.
+type SecretValueEphemeralResourceModel struct {
+	Region        types.String `tfsdk:"region"`
+	SecretId      types.String `tfsdk:"secret_id"`
+	VersionStage  types.String `tfsdk:"version_stage"`
+	ParameterName types.String `tfsdk:"parameter_name"`
+	JSONKeys      types.List   `tfsdk:"json_keys"`
+	Arn           types.String `tfsdk:"arn"`
+	VersionId     types.String `tfsdk:"version_id"`
+	Value         types.String `tfsdk:"value"`
+	Values        types.Map    `tfsdk:"values"`
+}
+
+// Metadata définit le nom du type de ressource éphémère utilisé dans les configurations Terraform.
+func (r *SecretValueEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "test_secret_value"
+}
+
+// Configure conserve la configuration du provider pour créer les clients de la région demandée.
+func (r *SecretValueEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	meta, diags := conns.FromProviderData(req.ProviderData, "secret value ephemeral resource")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
+		return
+	}
+
+	// Conserver la configuration du provider pour créer les clients de la région demandée
+	r.meta = meta
+}
+
+// Schema définit la structure et la documentation de la ressource éphémère.
+func (r *SecretValueEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `test_secret_value` ephemeral resource reads the value of an AWS Secrets Manager secret or of an SSM Parameter Store parameter (SecureString parameters are decrypted) for the current Terraform run, without storing it in the plan or state. Keys of a JSON secret can be extracted individually with `json_keys`. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"region": region.EphemeralResourceAttribute(),
+			"secret_id": schema.StringAttribute{
+				MarkdownDescription: "The name or ARN of the Secrets Manager secret to read. Exactly one of `secret_id` or `parameter_name` must be specified.",
+				Optional:            true,
+			},
+			"version_stage": schema.StringAttribute{
+				MarkdownDescription: "The staging label of the secret version to read, e.g. `AWSPREVIOUS`. Only valid with `secret_id`. Defaults to `AWSCURRENT`.",
+				Optional:            true,
+			},
+			"parameter_name": schema.StringAttribute{
+				MarkdownDescription: "The name or ARN of the SSM Parameter Store parameter to read. SecureString parameters are decrypted. Exactly one of `secret_id` or `parameter_name` must be specified.",
+				Optional:            true,
+			},
+			"json_keys": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Top-level keys to extract from the value, which must then be a JSON object. The extracted values are returned in `values`; string values are returned as is and other values as JSON.",
+				Optional:            true,
+			},
+			"arn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ARN of the secret or parameter.",
+			},
+			"version_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The version of the secret (version ID) or of the parameter (version number).",
+			},
+			"value": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The value of the secret or parameter.",
+			},
+			"values": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The values of the keys listed in `json_keys`, indexed by key. Null when `json_keys` is not specified.",
+			},
+		},
+	}
+}
+
+// Open lit la valeur du secret ou du paramètre et extrait les clés JSON demandées.
+func (r *SecretValueEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data SecretValueEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Vérifier qu'une seule source est spécifiée
+	hasSecret := !data.SecretId.IsNull() && data.SecretId.ValueString() != ""
+	hasParameter := !data.ParameterName.IsNull() && data.ParameterName.ValueString() != ""
+	if hasSecret == hasParameter {
+		resp.Diagnostics.AddError(
+			"Invalid configuration",
+			"Exactly one of secret_id or parameter_name must be specified. Please set secret_id to read a Secrets Manager secret or parameter_name to read an SSM parameter.",
+		)
+		return
+	}
+	if hasParameter && !data.VersionStage.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("version_stage"),
+			"Invalid configuration",
+			"version_stage can only be used with secret_id.",
+		)
+		return
+	}
+
+	// Lire la valeur depuis Secrets Manager ou Parameter Store, avec les clients de la région
+	// de la ressource éphémère
+	if hasSecret {
+		input := &secretsmanager.GetSecretValueInput{
+			SecretId: aws.String(data.SecretId.ValueString()),
+		}
+		if !data.VersionStage.IsNull() {
+			input.VersionStage = aws.String(data.VersionStage.ValueString())
+		}
+
+		output, err := r.meta.SecretsManagerClient(data.Region.ValueString()).GetSecretValue(ctx, input)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read secret from Secrets Manager",
+				fmt.Sprintf("Error calling AWS Secrets Manager GetSecretValue API for secret '%s': %s. Please verify your AWS credentials, permissions, and that the secret exists.", data.SecretId.ValueString(), err),
+			)
+			return
+		}
+		if output.SecretString == nil {
+			resp.Diagnostics.AddError(
+				"Unsupported secret value",
+				fmt.Sprintf("Secret '%s' contains a binary value. Only secrets stored as strings are supported.", data.SecretId.ValueString()),
+			)
+			return
+		}
+
+		data.Arn = types.StringValue(aws.ToString(output.ARN))
+		data.VersionId = types.StringValue(aws.ToString(output.VersionId))
+		data.Value = types.StringValue(aws.ToString(output.SecretString))
+	} else {
+		output, err := r.meta.SSMClient(data.Region.ValueString()).GetParameter(ctx, &ssm.GetParameterInput{
+			Name:           aws.String(data.ParameterName.ValueString()),
+			WithDecryption: aws.Bool(true),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read parameter from Parameter Store",
+				fmt.Sprintf("Error calling AWS SSM GetParameter API for parameter '%s': %s. Please verify your AWS credentials, permissions (including kms:Decrypt for SecureString parameters), and that the parameter exists.", data.ParameterName.ValueString(), err),
+			)
+			return
+		}
+
+		data.Arn = types.StringValue(aws.ToString(output.Parameter.ARN))
+		data.VersionId = types.StringValue(fmt.Sprintf("%d", output.Parameter.Version))
+		data.Value = types.StringValue(aws.ToString(output.Parameter.Value))
+	}
+
+	// Extraire les clés JSON demandées
+	data.Values = types.MapNull(types.StringType)
+	if !data.JSONKeys.IsNull() {
+		var keys []string
+		resp.Diagnostics.Append(data.JSONKeys.ElementsAs(ctx, &keys, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		values, diags := extractJSONKeys(data.Value.ValueString(), keys)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.Values, diags = types.MapValueFrom(ctx, types.StringType, values)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// extractJSONKeys extrait les clés demandées d'un objet JSON. Les valeurs de type chaîne
+// sont retournées telles quelles, les autres valeurs sous forme de JSON compact.
+// Les messages d'erreur ne contiennent jamais la valeur du secret.
+func extractJSONKeys(value string, keys []string) (map[string]string, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(value), &object); err != nil || object == nil {
+		diagnostics.AddAttributeError(
+			path.Root("json_keys"),
+			"Invalid JSON secret value",
+			"json_keys can only be used when the value is a JSON object. Please remove json_keys or store the value as a JSON object.",
+		)
+		return nil, diagnostics
+	}
+
+	values := make(map[string]string, len(keys))
+	for _, key := range keys {
+		raw, ok := object[key]
+		if !ok {
+			diagnostics.AddAttributeError(
+				path.Root("json_keys"),
+				"JSON key not found",
+				fmt.Sprintf("Key '%s' does not exist in the JSON value. Please verify the keys listed in json_keys.", key),
+			)
+			continue
+		}
+
+		var text string
+		if err := json.Unmarshal(raw, &text); err == nil {
+			values[key] = text
+			continue
+		}
+
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, raw); err != nil {
+			compact.Reset()
+			compact.Write(raw)
+		}
+		values[key] = compact.String()
+	}
+
+	return values, diagnostics
+}
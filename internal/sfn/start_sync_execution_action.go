@@ -0,0 +1,139 @@
+package sfn
+
+import (
+	"context"
+	"fmt"
+
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+	"github.com/jd-ucpa/terraform-provider-test/internal/failuremode"
+	"github.com/jd-ucpa/terraform-provider-test/internal/partition"
+	"github.com/jd-ucpa/terraform-provider-test/internal/region"
+	"github.com/jd-ucpa/terraform-provider-test/internal/timeouts"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &StartSyncExecutionAction{}
+var _ action.ActionWithConfigure = &StartSyncExecutionAction{}
+
+// NewStartSyncExecutionAction crée et retourne une nouvelle instance de l'action
+// StartSyncExecutionAction. Cette fonction est utilisée par le provider pour enregistrer
+// l'action dans Terraform.
+func NewStartSyncExecutionAction() action.Action {
+	return &StartSyncExecutionAction{}
+}
+
+// StartSyncExecutionAction exécute de manière synchrone une state machine Step Functions
+// lorsqu'elle est invoquée, sans rien enregistrer dans l'état.
+type StartSyncExecutionAction struct {
+	meta *conns.ProviderMeta
+}
+
+// StartSyncExecutionActionModel définit le modèle de données pour l'action StartSyncExecution.
+type StartSyncExecutionActionModel struct {
+	Region          types.String `tfsdk:"region"`
+	StateMachineArn types.String `tfsdk:"state_machine_arn"`
+	Name            types.String `tfsdk:"name"`
+	Input           types.String `tfsdk:"input"`
+	Timeout         types.String `tfsdk:"timeout"`
+}
+
+// Metadata définit le nom du type d'action utilisé dans les configurations Terraform.
+func (a *StartSyncExecutionAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = "test_sfn_start_sync_execution"
+}
+
+// Configure conserve la configuration du provider pour créer le client SFN de la région demandée.
+func (a *StartSyncExecutionAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	meta, diags := conns.FromProviderData(req.ProviderData, "SFN start sync execution action")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
+		return
+	}
+
+	// Conserver la configuration du provider pour créer les clients de la région de l'action
+	a.meta = meta
+}
+
+// Schema définit la structure et la documentation de l'action.
+func (a *StartSyncExecutionAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `test_sfn_start_sync_execution` action starts a synchronous execution of an AWS Step Functions Express state machine and waits for the result. The action fails if the execution does not succeed. Nothing is stored in the state. Requires Terraform 1.14 or later.",
+		Attributes: map[string]schema.Attribute{
+			"region": region.ActionAttribute(),
+			"state_machine_arn": schema.StringAttribute{
+				MarkdownDescription: "The ARN of the state machine to execute. The ARN is validated against the known AWS partitions (`aws`, `aws-cn`, `aws-us-gov`, ...).",
+				Required:            true,
+				Validators: []validator.String{
+					partition.ARNValidator("states"),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the execution. If not provided, AWS will generate a unique name.",
+				Optional:            true,
+			},
+			"input": schema.StringAttribute{
+				MarkdownDescription: "The JSON input data for the execution. Defaults to `{}` if not provided.",
+				Optional:            true,
+			},
+			"timeout": timeouts.ActionAttribute(startSyncExecutionTimeouts.Create),
+		},
+	}
+}
+
+// Invoke exécute la state machine et attend son résultat. L'exécution est celle de la
+// ressource test_sfn_start_sync_execution, avec failure_mode = "error".
+func (a *StartSyncExecutionAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config StartSyncExecutionActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Construire le modèle de la ressource à partir de la configuration de l'action. Le schéma
+	// d'une action n'ayant pas de valeur par défaut, l'input "{}" est appliqué ici.
+	data := StartSyncExecutionResourceModel{
+		Region:          config.Region,
+		StateMachineArn: config.StateMachineArn,
+		Name:            config.Name,
+		Input:           config.Input,
+		FailureMode:     types.StringValue(failuremode.Error),
+	}
+	if data.Input.IsNull() {
+		data.Input = types.StringValue("{}")
+	}
+	r := &StartSyncExecutionResource{meta: a.meta}
+
+	// Récupérer le délai d'attente de l'exécution
+	timeout, diags := timeouts.Action(config.Timeout, startSyncExecutionTimeouts.Create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Starting sync execution of state machine '%s'", data.StateMachineArn.ValueString()),
+	})
+
+	// Utiliser le client de la région de l'action
+	conn := a.meta.SFNClient(data.Region.ValueString())
+
+	resp.Diagnostics.Append(r.startSyncExecution(ctx, conn, &data, timeout)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Une exécution en échec fait échouer l'action
+	if data.Status.ValueString() != string(sfntypes.SyncExecutionStatusSucceeded) {
+		resp.Diagnostics.Append(failuremode.Diagnostics(data.FailureMode, "SFN execution failed", executionFailureDetail(data))...)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Execution '%s' completed with status '%s'", data.ExecutionArn.ValueString(), data.Status.ValueString()),
+	})
+}
@@ -2,17 +2,39 @@ package sfn
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+	"github.com/jd-ucpa/terraform-provider-test/internal/failuremode"
+	"github.com/jd-ucpa/terraform-provider-test/internal/region"
+	"github.com/jd-ucpa/terraform-provider-test/internal/partition"
+	"github.com/jd-ucpa/terraform-provider-test/internal/timeouts"
+	"github.com/jd-ucpa/terraform-provider-test/internal/triggers"
 )
 
+// startSyncExecutionTimeouts définit le délai par défaut d'une exécution synchrone.
+// Il correspond à la durée maximale d'une exécution de workflow Express (5 minutes).
+// Seule la création lance une exécution.
+var startSyncExecutionTimeouts = timeouts.Defaults{
+	Create: 5 * time.Minute,
+}
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &StartSyncExecutionResource{}
 
@@ -26,25 +48,29 @@ func NewStartSyncExecutionResource() resource.Resource {
 // StartSyncExecutionResource gère l'exécution synchrone d'une state machine AWS Step Functions.
 // Cette ressource permet d'exécuter une state machine et d'attendre le résultat de manière synchrone.
 type StartSyncExecutionResource struct {
-	sfn *sfn.Client
+	meta *conns.ProviderMeta
 }
 
 // StartSyncExecutionResourceModel définit le modèle de données pour la ressource StartSyncExecution.
 // Il contient tous les attributs de configuration et les données retournées par l'API SFN.
 type StartSyncExecutionResourceModel struct {
-	Id               types.String `tfsdk:"id"`
-	StateMachineArn  types.String `tfsdk:"state_machine_arn"`
-	Name             types.String `tfsdk:"name"`
-	Input            types.String `tfsdk:"input"`
-	Triggers         types.Map    `tfsdk:"triggers"`
-	ExecutionArn     types.String `tfsdk:"execution_arn"`
-	Status           types.String `tfsdk:"status"`
-	Output           types.String `tfsdk:"output"`
-	Error            types.String `tfsdk:"error"`
-	Cause            types.String `tfsdk:"cause"`
-	BillingDetails   types.Object `tfsdk:"billing_details"`
-	StartDate        types.String `tfsdk:"start_date"`
-	StopDate         types.String `tfsdk:"stop_date"`
+	Id              types.String  `tfsdk:"id"`
+	Region          types.String  `tfsdk:"region"`
+	StateMachineArn types.String  `tfsdk:"state_machine_arn"`
+	Name            types.String  `tfsdk:"name"`
+	Input           types.String  `tfsdk:"input"`
+	Triggers        types.Map     `tfsdk:"triggers"`
+	TriggersReplace types.Dynamic `tfsdk:"triggers_replace"`
+	FailureMode     types.String  `tfsdk:"failure_mode"`
+	ExecutionArn    types.String  `tfsdk:"execution_arn"`
+	Status          types.String  `tfsdk:"status"`
+	Output          types.String  `tfsdk:"output"`
+	Error           types.String  `tfsdk:"error"`
+	Cause           types.String  `tfsdk:"cause"`
+	BillingDetails  types.Object  `tfsdk:"billing_details"`
+	StartDate       types.String  `tfsdk:"start_date"`
+	StopDate        types.String  `tfsdk:"stop_date"`
+	Timeouts        types.Object  `tfsdk:"timeouts"`
 }
 
 // BillingDetailsModel définit le modèle pour les détails de facturation.
@@ -63,23 +89,14 @@ func (r *StartSyncExecutionResource) Metadata(ctx context.Context, req resource.
 // Cette méthode est appelée par Terraform pour configurer la ressource avec
 // les paramètres d'authentification AWS (région, credentials, etc.).
 func (r *StartSyncExecutionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	// Éviter le panic si le provider n'a pas été configuré
-	if req.ProviderData == nil {
+	meta, diags := conns.FromProviderData(req.ProviderData, "SFN start sync execution resource")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
 		return
 	}
 
-	// Vérifier que la configuration est du bon type
-	config, ok := req.ProviderData.(aws.Config)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Provider configuration error",
-			fmt.Sprintf("Expected aws.Config for SFN start sync execution resource, got: %T. This indicates a provider configuration issue. Please verify your provider configuration and report this issue if it persists.", req.ProviderData),
-		)
-		return
-	}
-	
-	// Créer le client SFN à partir de la configuration AWS
-	r.sfn = sfn.NewFromConfig(config)
+	// Conserver la configuration du provider pour créer les clients de la région demandée
+	r.meta = meta
 }
 
 // Schema définit la structure et la documentation de la ressource.
@@ -92,10 +109,17 @@ func (r *StartSyncExecutionResource) Schema(ctx context.Context, req resource.Sc
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The unique identifier for this execution.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
+			"region": region.ResourceAttribute(),
 			"state_machine_arn": schema.StringAttribute{
-				MarkdownDescription: "The ARN of the state machine to execute.",
+				MarkdownDescription: "The ARN of the state machine to execute. The ARN is validated against the known AWS partitions (`aws`, `aws-cn`, `aws-us-gov`, ...).",
 				Required:            true,
+				Validators: []validator.String{
+					partition.ARNValidator("states"),
+				},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "The name of the execution. If not provided, AWS will generate a unique name.",
@@ -107,34 +131,50 @@ func (r *StartSyncExecutionResource) Schema(ctx context.Context, req resource.Sc
 				Computed:            true,
 				Default:             stringdefault.StaticString("{}"),
 			},
-			"triggers": schema.MapAttribute{
-				ElementType:         types.StringType,
-				MarkdownDescription: "A map of arbitrary strings that, when changed, will force the resource to be recreated.",
-				Optional:            true,
-			},
+			"triggers":         triggers.ResourceAttribute(),
+			"triggers_replace": triggers.ReplaceResourceAttribute(),
+			"failure_mode":     failuremode.ResourceAttribute(),
 			"execution_arn": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ARN of the execution.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"status": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The status of the execution (SUCCEEDED, FAILED, TIMED_OUT, ABORTED).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"output": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The JSON output data of the execution.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"error": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The error name if the execution failed (e.g., States.DataLimitExceeded).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"cause": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The error cause if the execution failed, providing detailed information about the failure.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"billing_details": schema.SingleNestedAttribute{
 				Computed:            true,
 				MarkdownDescription: "The billing details of the execution.",
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
 				Attributes: map[string]schema.Attribute{
 					"billed_duration_in_milliseconds": schema.Int64Attribute{
 						Computed:            true,
@@ -149,12 +189,21 @@ func (r *StartSyncExecutionResource) Schema(ctx context.Context, req resource.Sc
 			"start_date": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The date and time when the execution started.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"stop_date": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The date and time when the execution stopped.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(startSyncExecutionTimeouts),
+		},
 	}
 }
 
@@ -168,100 +217,25 @@ func (r *StartSyncExecutionResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
-	// Utiliser l'input (la valeur par défaut "{}" est gérée par le schéma)
-	input := data.Input.ValueString()
-
-	// Préparer les paramètres pour l'API SFN
-	inputParams := &sfn.StartSyncExecutionInput{
-		StateMachineArn: aws.String(data.StateMachineArn.ValueString()),
-		Input:           aws.String(input),
-	}
-
-	// Ajouter le nom si spécifié
-	if !data.Name.IsNull() && !data.Name.IsUnknown() {
-		inputParams.Name = aws.String(data.Name.ValueString())
-	}
+	// Utiliser le client de la région de la ressource
+	conn := r.meta.SFNClient(data.Region.ValueString())
 
-	// Démarrer l'exécution synchrone
-	result, err := r.sfn.StartSyncExecution(ctx, inputParams)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to start SFN sync execution",
-			fmt.Sprintf("Error calling AWS Step Functions StartSyncExecution API for state machine '%s': %s. Please verify your AWS credentials, permissions, and that the state machine exists and is accessible.", data.StateMachineArn.ValueString(), err),
-		)
+	// Démarrer l'exécution synchrone en respectant le délai du bloc timeouts
+	createTimeout, diags := timeouts.Create(data.Timeouts, startSyncExecutionTimeouts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Remplir les données de réponse
-	data.Id = types.StringValue(*result.ExecutionArn)
-	data.ExecutionArn = types.StringValue(*result.ExecutionArn)
-	data.Status = types.StringValue(string(result.Status))
-	
-	if result.Output != nil {
-		data.Output = types.StringValue(*result.Output)
-	} else {
-		data.Output = types.StringValue("")
-	}
-
-	// Gérer les champs d'erreur
-	if result.Error != nil {
-		data.Error = types.StringValue(*result.Error)
-	} else {
-		data.Error = types.StringNull()
-	}
-
-	if result.Cause != nil {
-		data.Cause = types.StringValue(*result.Cause)
-	} else {
-		data.Cause = types.StringNull()
-	}
-
-	// Préserver la valeur d'input du plan (ne pas la modifier)
-	// data.Input reste inchangé car il vient du plan
-
-
-	// Remplir les détails de facturation
-	if result.BillingDetails != nil {
-		billingDetails := BillingDetailsModel{
-			BilledDurationInMilliseconds: types.Int64Value(result.BillingDetails.BilledDurationInMilliseconds),
-			BilledMemoryUsedInMB:         types.Int64Value(result.BillingDetails.BilledMemoryUsedInMB),
-		}
-		billingDetailsObj, diag := types.ObjectValueFrom(ctx, map[string]attr.Type{
-			"billed_duration_in_milliseconds": types.Int64Type,
-			"billed_memory_used_in_mb":         types.Int64Type,
-		}, billingDetails)
-		if diag.HasError() {
-			resp.Diagnostics.Append(diag...)
-			return
-		}
-		data.BillingDetails = billingDetailsObj
-	} else {
-		// Créer un objet vide pour les détails de facturation
-		billingDetailsObj, diag := types.ObjectValueFrom(ctx, map[string]attr.Type{
-			"billed_duration_in_milliseconds": types.Int64Type,
-			"billed_memory_used_in_mb":         types.Int64Type,
-		}, BillingDetailsModel{
-			BilledDurationInMilliseconds: types.Int64Value(0),
-			BilledMemoryUsedInMB:         types.Int64Value(0),
-		})
-		if diag.HasError() {
-			resp.Diagnostics.Append(diag...)
-			return
-		}
-		data.BillingDetails = billingDetailsObj
-	}
-
-	// Remplir les dates
-	if result.StartDate != nil {
-		data.StartDate = types.StringValue(result.StartDate.Format("2006-01-02T15:04:05.000Z"))
-	} else {
-		data.StartDate = types.StringValue("")
+	resp.Diagnostics.Append(r.startSyncExecution(ctx, conn, &data, createTimeout)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if result.StopDate != nil {
-		data.StopDate = types.StringValue(result.StopDate.Format("2006-01-02T15:04:05.000Z"))
-	} else {
-		data.StopDate = types.StringValue("")
+	// Signaler l'échec de l'exécution selon failure_mode. L'état est enregistré même avec
+	// failure_mode = "error" : Terraform marque alors la ressource comme tainted.
+	if data.Status.ValueString() != string(sfntypes.SyncExecutionStatusSucceeded) {
+		resp.Diagnostics.Append(failuremode.Diagnostics(data.FailureMode, "SFN execution failed", executionFailureDetail(data))...)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -284,7 +258,9 @@ func (r *StartSyncExecutionResource) Read(ctx context.Context, req resource.Read
 
 // Update gère les modifications de la ressource.
 // Cette méthode est appelée par Terraform lors de la modification d'une ressource existante.
-// Elle vérifie si les triggers ont changé et exécute une nouvelle exécution si nécessaire.
+// Un changement de triggers ou de triggers_replace entraîne le remplacement de la ressource :
+// une mise à jour ne démarre donc jamais d'exécution et enregistre simplement le plan, dont
+// les valeurs calculées sont conservées par les plan modifiers UseStateForUnknown.
 func (r *StartSyncExecutionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data StartSyncExecutionResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -292,17 +268,21 @@ func (r *StartSyncExecutionResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
-	// Récupérer l'état actuel pour comparer les triggers
-	var currentData StartSyncExecutionResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &currentData)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
 
-	// Vérifier si les triggers ont changé
-	triggersChanged := !data.Triggers.Equal(currentData.Triggers)
+// Delete gère la suppression de la ressource.
+// Cette méthode est appelée par Terraform lors de la suppression d'une ressource.
+// Les exécutions SFN ne peuvent pas être supprimées, on ne fait rien.
+func (r *StartSyncExecutionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Les exécutions SFN ne peuvent pas être supprimées, on ne fait rien
+}
 
-	if triggersChanged {
+// startSyncExecution démarre une exécution synchrone de la state machine, attend son résultat
+// pendant au plus timeout et le mappe vers le modèle. Une exécution en échec n'est pas une
+// erreur : c'est à l'appelant de la signaler selon failure_mode.
+func (r *StartSyncExecutionResource) startSyncExecution(ctx context.Context, conn *sfn.Client, data *StartSyncExecutionResourceModel, timeout time.Duration) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
 
 	// Utiliser l'input (la valeur par défaut "{}" est gérée par le schéma)
 	input := data.Input.ValueString()
@@ -318,22 +298,44 @@ func (r *StartSyncExecutionResource) Update(ctx context.Context, req resource.Up
 		inputParams.Name = aws.String(data.Name.ValueString())
 	}
 
-	// Démarrer l'exécution synchrone
-	result, err := r.sfn.StartSyncExecution(ctx, inputParams)
+	// StartSyncExecution ne retourne qu'une fois l'exécution terminée : il n'y a pas d'état à
+	// relire périodiquement avec le package waiter, et relancer l'appel démarrerait une seconde
+	// exécution. Le délai est donc appliqué directement à l'appel, avec les mêmes logs que waiter.
+	executionCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	executionCtx = tflog.SetField(executionCtx, "operation", fmt.Sprintf("SFN sync execution of state machine '%s'", data.StateMachineArn.ValueString()))
+	tflog.Info(executionCtx, "Waiting for operation to complete", map[string]any{
+		"timeout": timeout.String(),
+	})
+	start := time.Now()
+
+	result, err := conn.StartSyncExecution(executionCtx, inputParams)
+	if err != nil && errors.Is(executionCtx.Err(), context.DeadlineExceeded) {
+		diagnostics.AddError(
+			"Timeout while waiting for SFN execution to complete",
+			fmt.Sprintf("The execution of state machine '%s' did not complete within %s. Increase the timeout if the state machine needs more time.", data.StateMachineArn.ValueString(), timeout),
+		)
+		return diagnostics
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diagnostics.AddError(
 			"Unable to start SFN sync execution",
 			fmt.Sprintf("Error calling AWS Step Functions StartSyncExecution API for state machine '%s': %s. Please verify your AWS credentials, permissions, and that the state machine exists and is accessible.", data.StateMachineArn.ValueString(), err),
 		)
-		return
+		return diagnostics
 	}
 
-	// Mettre à jour les données avec le résultat
+	tflog.Info(executionCtx, "Operation completed", map[string]any{
+		"state":   string(result.Status),
+		"elapsed": time.Since(start).Round(time.Millisecond).String(),
+	})
+
+	// Remplir les données de réponse
 	data.Id = types.StringValue(*result.ExecutionArn)
 	data.ExecutionArn = types.StringValue(*result.ExecutionArn)
 	data.Status = types.StringValue(string(result.Status))
 	
-	// Gérer le cas où Output peut être nil (ex: quand la Step Function échoue)
 	if result.Output != nil {
 		data.Output = types.StringValue(*result.Output)
 	} else {
@@ -353,20 +355,23 @@ func (r *StartSyncExecutionResource) Update(ctx context.Context, req resource.Up
 		data.Cause = types.StringNull()
 	}
 
-	// Gérer les détails de facturation
+	// Préserver la valeur d'input du plan (ne pas la modifier)
+	// data.Input reste inchangé car il vient du plan
+
+
+	// Remplir les détails de facturation
 	if result.BillingDetails != nil {
 		billingDetails := BillingDetailsModel{
 			BilledDurationInMilliseconds: types.Int64Value(result.BillingDetails.BilledDurationInMilliseconds),
 			BilledMemoryUsedInMB:         types.Int64Value(result.BillingDetails.BilledMemoryUsedInMB),
 		}
-
 		billingDetailsObj, diag := types.ObjectValueFrom(ctx, map[string]attr.Type{
 			"billed_duration_in_milliseconds": types.Int64Type,
 			"billed_memory_used_in_mb":         types.Int64Type,
 		}, billingDetails)
 		if diag.HasError() {
-			resp.Diagnostics.Append(diag...)
-			return
+			diagnostics.Append(diag...)
+			return diagnostics
 		}
 		data.BillingDetails = billingDetailsObj
 	} else {
@@ -379,8 +384,8 @@ func (r *StartSyncExecutionResource) Update(ctx context.Context, req resource.Up
 			BilledMemoryUsedInMB:         types.Int64Value(0),
 		})
 		if diag.HasError() {
-			resp.Diagnostics.Append(diag...)
-			return
+			diagnostics.Append(diag...)
+			return diagnostics
 		}
 		data.BillingDetails = billingDetailsObj
 	}
@@ -398,45 +403,11 @@ func (r *StartSyncExecutionResource) Update(ctx context.Context, req resource.Up
 		data.StopDate = types.StringValue("")
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-	} else {
-		// Si les triggers n'ont pas changé, préserver les valeurs calculées
-		if data.Id.IsUnknown() || data.Id.IsNull() {
-			data.Id = currentData.Id
-		}
-		if data.ExecutionArn.IsUnknown() || data.ExecutionArn.IsNull() {
-			data.ExecutionArn = currentData.ExecutionArn
-		}
-		if data.Status.IsUnknown() || data.Status.IsNull() {
-			data.Status = currentData.Status
-		}
-		if data.Output.IsUnknown() || data.Output.IsNull() {
-			data.Output = currentData.Output
-		}
-		if data.Error.IsUnknown() || data.Error.IsNull() {
-			data.Error = currentData.Error
-		}
-		if data.Cause.IsUnknown() || data.Cause.IsNull() {
-			data.Cause = currentData.Cause
-		}
-		if data.BillingDetails.IsUnknown() || data.BillingDetails.IsNull() {
-			data.BillingDetails = currentData.BillingDetails
-		}
-		if data.StartDate.IsUnknown() || data.StartDate.IsNull() {
-			data.StartDate = currentData.StartDate
-		}
-		if data.StopDate.IsUnknown() || data.StopDate.IsNull() {
-			data.StopDate = currentData.StopDate
-		}
-
-		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-	}
+	return diagnostics
 }
 
-// Delete gère la suppression de la ressource.
-// Cette méthode est appelée par Terraform lors de la suppression d'une ressource.
-// Les exécutions SFN ne peuvent pas être supprimées, on ne fait rien.
-func (r *StartSyncExecutionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// Les exécutions SFN ne peuvent pas être supprimées, on ne fait rien
+// executionFailureDetail décrit une exécution en échec pour le diagnostic de failure_mode.
+func executionFailureDetail(data StartSyncExecutionResourceModel) string {
+	return fmt.Sprintf("Execution '%s' completed with status '%s'.\n\nError: %s\nCause: %s",
+		data.ExecutionArn.ValueString(), data.Status.ValueString(), data.Error.ValueString(), data.Cause.ValueString())
 }
-
@@ -11,6 +11,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+	"github.com/jd-ucpa/terraform-provider-test/internal/region"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -27,13 +29,14 @@ func NewActivationDataSource() datasource.DataSource {
 // Ce data source permet de récupérer les détails d'une activation SSM spécifique
 // en utilisant son ID d'activation.
 type ActivationDataSource struct {
-	ssm *ssm.Client
+	meta *conns.ProviderMeta
 }
 
 // ActivationDataSourceModel définit le modèle de données pour le data source Activation.
 // Il contient tous les attributs de configuration et les données retournées par l'API SSM.
 type ActivationDataSourceModel struct {
 	Id                  types.String `tfsdk:"id"`
+	Region              types.String `tfsdk:"region"`
 	ActivationId        types.String `tfsdk:"activation_id"`
 	IamRole             types.String `tfsdk:"iam_role"`
 	RegistrationLimit   types.Int64  `tfsdk:"registration_limit"`
@@ -53,23 +56,14 @@ func (d *ActivationDataSource) Metadata(ctx context.Context, req datasource.Meta
 // Cette méthode est appelée par Terraform pour configurer le data source avec
 // les paramètres d'authentification AWS (région, credentials, etc.).
 func (d *ActivationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	// Éviter le panic si le provider n'a pas été configuré
-	if req.ProviderData == nil {
+	meta, diags := conns.FromProviderData(req.ProviderData, "SSM activation data source")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
 		return
 	}
 
-	// Vérifier que la configuration est du bon type
-	config, ok := req.ProviderData.(aws.Config)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Provider configuration error",
-			fmt.Sprintf("Expected aws.Config for SSM activation data source, got: %T. This indicates a provider configuration issue. Please verify your provider configuration and report this issue if it persists.", req.ProviderData),
-		)
-		return
-	}
-	
-	// Créer le client SSM à partir de la configuration AWS
-	d.ssm = ssm.NewFromConfig(config)
+	// Conserver la configuration du provider pour créer les clients de la région demandée
+	d.meta = meta
 }
 
 // Schema définit la structure et la documentation du data source.
@@ -83,6 +77,7 @@ func (d *ActivationDataSource) Schema(ctx context.Context, req datasource.Schema
 				Computed:            true,
 				MarkdownDescription: "The activation ID (same as activation_id).",
 			},
+			"region": region.DataSourceAttribute(),
 			"activation_id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the SSM activation to retrieve information for.",
 				Required:            true,
@@ -125,6 +120,9 @@ func (d *ActivationDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
+	// Utiliser le client de la région de la configuration
+	conn := d.meta.SSMClient(data.Region.ValueString())
+
 	activationId := data.ActivationId.ValueString()
 	if activationId == "" {
 		resp.Diagnostics.AddError(
@@ -145,7 +143,7 @@ func (d *ActivationDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	}
 
 	// Appeler l'API SSM DescribeActivations
-	output, err := d.ssm.DescribeActivations(ctx, input)
+	output, err := conn.DescribeActivations(ctx, input)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to retrieve SSM activation",
@@ -0,0 +1,260 @@
+package ssm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+	"github.com/jd-ucpa/terraform-provider-test/internal/partition"
+	"github.com/jd-ucpa/terraform-provider-test/internal/region"
+	"github.com/jd-ucpa/terraform-provider-test/internal/tags"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &ActivationEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ActivationEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &ActivationEphemeralResource{}
+
+// activationPrivateKey est la clé des données privées transmises de Open à Close.
+const activationPrivateKey = "activation"
+
+// defaultActivationExpiresIn est la durée de validité par défaut d'une activation éphémère.
+const defaultActivationExpiresIn = time.Hour
+
+// maxActivationExpiresIn est la durée de validité maximale d'une activation SSM (30 jours).
+const maxActivationExpiresIn = 30 * 24 * time.Hour
+
+// NewActivationEphemeralResource crée et retourne une nouvelle instance de la ressource éphémère
+// ActivationEphemeralResource. Cette fonction est utilisée par le provider pour enregistrer
+// la ressource éphémère dans Terraform.
+func NewActivationEphemeralResource() ephemeral.EphemeralResource {
+	return &ActivationEphemeralResource{}
+}
+
+// ActivationEphemeralResource crée une activation SSM de courte durée pour l'exécution en cours.
+// Contrairement à la ressource test_ssm_activation, le code d'activation n'est jamais enregistré
+// dans l'état ni dans Secrets Manager : l'activation est supprimée à la fermeture (Close).
+type ActivationEphemeralResource struct {
+	meta        *conns.ProviderMeta
+	defaultTags map[string]string
+}
+
+// ActivationEphemeralResourceModel définit le modèle de données pour la ressource éphémère Activation.
+type ActivationEphemeralResourceModel struct {
+	Region            types.String `tfsdk:"region"`
+	Description       types.String `tfsdk:"description"`
+	IamRole           types.String `tfsdk:"iam_role"`
+	RegistrationLimit types.Int64  `tfsdk:"registration_limit"`
+	ExpiresIn         types.String `tfsdk:"expires_in"`
+	Tags              types.Map    `tfsdk:"tags"`
+	ActivationId      types.String `tfsdk:"activation_id"`
+	ActivationCode    types.String `tfsdk:"activation_code"`
+	ExpirationDate    types.String `tfsdk:"expiration_date"`
+}
+
+// activationPrivateData contient les informations nécessaires à Close pour supprimer l'activation.
+type activationPrivateData struct {
+	ActivationId string `json:"activation_id"`
+	Region       string `json:"region"`
+}
+
+// Metadata définit le nom du type de ressource éphémère utilisé dans les configurations Terraform.
+func (r *ActivationEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "test_ssm_activation"
+}
+
+// Configure conserve la configuration du provider pour créer le client SSM de la région demandée.
+func (r *ActivationEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	meta, diags := conns.FromProviderData(req.ProviderData, "SSM activation ephemeral resource")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
+		return
+	}
+
+	// Conserver la configuration du provider pour créer les clients de la région demandée
+	r.meta = meta
+	r.defaultTags = meta.DefaultTags
+}
+
+// Schema définit la structure et la documentation de la ressource éphémère.
+func (r *ActivationEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `test_ssm_activation` ephemeral resource creates a short-lived SSM activation for the current Terraform run. The activation ID and code are never stored in the plan or state, and the activation is deleted when Terraform closes the ephemeral resource. Instances already registered with the activation stay registered. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"region": region.EphemeralResourceAttribute(),
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the SSM activation.",
+				Optional:            true,
+			},
+			"iam_role": schema.StringAttribute{
+				MarkdownDescription: "The name of the IAM role to assign to the instances registered with the SSM activation, e.g. `SSMServiceRole`. An IAM role ARN is also accepted.",
+				Required:            true,
+				Validators: []validator.String{
+					partition.RoleValidator(),
+				},
+			},
+			"registration_limit": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of managed instances that can be registered using this activation. Defaults to 1.",
+				Optional:            true,
+			},
+			"expires_in": schema.StringAttribute{
+				MarkdownDescription: "How long the activation stays valid, as a duration (e.g. `30m`, `2h`). Must not exceed 30 days (`720h`). Defaults to `1h`.",
+				Optional:            true,
+			},
+			"tags": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "A map of tags to assign to the SSM activation. Tags are merged with the provider `default_tags`; tags defined here override default tags with the same key.",
+				Optional:            true,
+			},
+			"activation_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The activation ID for the SSM activation.",
+			},
+			"activation_code": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The activation code for the SSM activation.",
+			},
+			"expiration_date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The expiration date of the SSM activation in RFC3339 format.",
+			},
+		},
+	}
+}
+
+// Open crée l'activation SSM et retourne son identifiant et son code pour l'exécution en cours.
+func (r *ActivationEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ActivationEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Utiliser le client de la région de la ressource éphémère
+	conn := r.meta.SSMClient(data.Region.ValueString())
+
+	// Calculer la date d'expiration
+	expiresIn := defaultActivationExpiresIn
+	if !data.ExpiresIn.IsNull() {
+		parsed, err := time.ParseDuration(data.ExpiresIn.ValueString())
+		if err != nil || parsed <= 0 || parsed > maxActivationExpiresIn {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expires_in"),
+				"Invalid expires_in configuration",
+				fmt.Sprintf(`"expires_in" (%s) must be a positive duration of at most 30 days (e.g. "30m", "2h", "720h").`, data.ExpiresIn.ValueString()),
+			)
+			return
+		}
+		expiresIn = parsed
+	}
+	expirationDate := time.Now().UTC().Add(expiresIn)
+
+	// Préparer les tags (tags de la ressource fusionnés avec les default_tags du provider)
+	tagsAll, diags := tags.TagsAll(ctx, r.defaultTags, data.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tagMap := make(map[string]string)
+	resp.Diagnostics.Append(tagsAll.ElementsAs(ctx, &tagMap, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Créer l'activation SSM
+	createInput := &ssm.CreateActivationInput{
+		IamRole:           aws.String(data.IamRole.ValueString()),
+		RegistrationLimit: aws.Int32(1),
+		ExpirationDate:    aws.Time(expirationDate),
+	}
+
+	if !data.Description.IsNull() {
+		createInput.Description = aws.String(data.Description.ValueString())
+	}
+
+	if !data.RegistrationLimit.IsNull() {
+		createInput.RegistrationLimit = aws.Int32(int32(data.RegistrationLimit.ValueInt64()))
+	}
+
+	for _, key := range tags.Keys(tagMap) {
+		createInput.Tags = append(createInput.Tags, ssmtypes.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(tagMap[key]),
+		})
+	}
+
+	createOutput, err := conn.CreateActivation(ctx, createInput)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create SSM activation",
+			fmt.Sprintf("Error calling AWS SSM CreateActivation API: %s. Please verify your AWS credentials, permissions, and that you have the necessary IAM permissions to create SSM activations.", err),
+		)
+		return
+	}
+
+	// Conserver l'identifiant de l'activation pour la supprimer dans Close
+	private, err := json.Marshal(activationPrivateData{
+		ActivationId: aws.ToString(createOutput.ActivationId),
+		Region:       data.Region.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to save SSM activation data",
+			fmt.Sprintf("Error encoding the data of activation '%s': %s. The activation must be deleted manually.", aws.ToString(createOutput.ActivationId), err),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, activationPrivateKey, private)...)
+
+	// Mettre à jour le modèle avec les données retournées
+	data.ActivationId = types.StringValue(aws.ToString(createOutput.ActivationId))
+	data.ActivationCode = types.StringValue(aws.ToString(createOutput.ActivationCode))
+	data.ExpirationDate = types.StringValue(expirationDate.Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// Close supprime l'activation SSM créée par Open. Une activation déjà supprimée ou expirée
+// n'est pas considérée comme une erreur.
+func (r *ActivationEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	private, diags := req.Private.GetKey(ctx, activationPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || private == nil {
+		return
+	}
+
+	var data activationPrivateData
+	if err := json.Unmarshal(private, &data); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read SSM activation data",
+			fmt.Sprintf("Error decoding the data saved when the activation was created: %s. The activation must be deleted manually.", err),
+		)
+		return
+	}
+
+	// Utiliser le client de la région dans laquelle l'activation a été créée
+	_, err := r.meta.SSMClient(data.Region).DeleteActivation(ctx, &ssm.DeleteActivationInput{
+		ActivationId: aws.String(data.ActivationId),
+	})
+
+	var notFound *ssmtypes.InvalidActivation
+	if err != nil && !errors.As(err, &notFound) {
+		resp.Diagnostics.AddError(
+			"Unable to delete SSM activation",
+			fmt.Sprintf("Error calling AWS SSM DeleteActivation API for activation '%s': %s. Please verify your AWS credentials, permissions, and delete the activation manually if needed.", data.ActivationId, err),
+		)
+	}
+}
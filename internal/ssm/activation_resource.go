@@ -8,9 +8,11 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	secretsmanagertypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -19,11 +21,25 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+	"github.com/jd-ucpa/terraform-provider-test/internal/partition"
+	"github.com/jd-ucpa/terraform-provider-test/internal/region"
+	"github.com/jd-ucpa/terraform-provider-test/internal/tags"
+	"github.com/jd-ucpa/terraform-provider-test/internal/timeouts"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ActivationResource{}
+var _ resource.ResourceWithModifyPlan = &ActivationResource{}
+
+// activationTimeouts définit les délais par défaut des opérations sur une activation SSM.
+var activationTimeouts = timeouts.Defaults{
+	Create: 5 * time.Minute,
+	Update: 5 * time.Minute,
+	Delete: 5 * time.Minute,
+}
 
 // NewActivationResource crée et retourne une nouvelle instance de la ressource
 // ActivationResource. Cette fonction est utilisée par le provider pour enregistrer
@@ -36,8 +52,8 @@ func NewActivationResource() resource.Resource {
 // Cette ressource permet de créer des activations SSM avec support du renouvellement
 // automatique, de la gestion des secrets et de la configuration d'expiration.
 type ActivationResource struct {
-	ssm               *ssm.Client
-	secretsManager    *secretsmanager.Client
+	meta        *conns.ProviderMeta
+	defaultTags map[string]string
 }
 
 // ExpirationDateModel définit le modèle pour le bloc expiration_date de la ressource.
@@ -52,10 +68,12 @@ type ExpirationDateModel struct {
 // Il contient tous les attributs de configuration et les données retournées par l'API SSM.
 type ActivationResourceModel struct {
 	Id              types.String         `tfsdk:"id"`
+	Region          types.String         `tfsdk:"region"`
 	Description     types.String         `tfsdk:"description"`
 	IamRole         types.String         `tfsdk:"iam_role"`
 	RegistrationLimit types.Int64        `tfsdk:"registration_limit"`
 	Tags            types.Map            `tfsdk:"tags"`
+	TagsAll         types.Map            `tfsdk:"tags_all"`
 	ActivationCode  types.String         `tfsdk:"activation_code"`
 	ActivationId    types.String         `tfsdk:"activation_id"`
 	Expired         types.Bool           `tfsdk:"expired"`
@@ -64,6 +82,7 @@ type ActivationResourceModel struct {
 	SecretArn       types.String         `tfsdk:"secret_arn"`
 	SecretVersion   types.String         `tfsdk:"secret_version"`
 	Managed         types.Bool           `tfsdk:"managed"`
+	Timeouts        types.Object         `tfsdk:"timeouts"`
 }
 
 // Metadata définit le nom du type de ressource utilisé dans les configurations Terraform.
@@ -76,24 +95,15 @@ func (r *ActivationResource) Metadata(ctx context.Context, req resource.Metadata
 // Cette méthode est appelée par Terraform pour configurer la ressource avec
 // les paramètres d'authentification AWS (région, credentials, etc.).
 func (r *ActivationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	// Éviter le panic si le provider n'a pas été configuré
-	if req.ProviderData == nil {
+	meta, diags := conns.FromProviderData(req.ProviderData, "SSM activation resource")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
 		return
 	}
 
-	// Vérifier que la configuration est du bon type
-	config, ok := req.ProviderData.(aws.Config)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Provider configuration error",
-			fmt.Sprintf("Expected aws.Config for SSM activation resource, got: %T. This indicates a provider configuration issue. Please verify your provider configuration and report this issue if it persists.", req.ProviderData),
-		)
-		return
-	}
-	
-	// Créer les clients AWS à partir de la configuration
-	r.ssm = ssm.NewFromConfig(config)
-	r.secretsManager = secretsmanager.NewFromConfig(config)
+	// Conserver la configuration du provider pour créer les clients de la région de la ressource
+	r.meta = meta
+	r.defaultTags = meta.DefaultTags
 }
 
 // Schema définit la structure et la documentation de la ressource.
@@ -110,6 +120,7 @@ func (r *ActivationResource) Schema(ctx context.Context, req resource.SchemaRequ
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"region": region.ResourceAttribute(),
 			"description": schema.StringAttribute{
 				MarkdownDescription: "The description of the SSM activation.",
 				Optional:            true,
@@ -118,8 +129,11 @@ func (r *ActivationResource) Schema(ctx context.Context, req resource.SchemaRequ
 				},
 			},
 			"iam_role": schema.StringAttribute{
-				MarkdownDescription: "The IAM role name to use for the SSM activation.",
+				MarkdownDescription: "The name of the IAM role to assign to the instances registered with the SSM activation, e.g. `SSMServiceRole`. An IAM role ARN is also accepted.",
 				Required:            true,
+				Validators: []validator.String{
+					partition.RoleValidator(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -166,14 +180,16 @@ func (r *ActivationResource) Schema(ctx context.Context, req resource.SchemaRequ
 			},
 			"tags": schema.MapAttribute{
 				ElementType:         types.StringType,
-				MarkdownDescription: "A map of tags to assign to the SSM activation.",
+				MarkdownDescription: "A map of tags to assign to the SSM activation and, when `managed = true`, to the secret it creates. Tags are merged with the provider `default_tags`; tags defined here override default tags with the same key.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.Map{
 					mapplanmodifier.RequiresReplace(),
 				},
 			},
+			"tags_all": tags.TagsAllAttribute(),
 		},
 		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(activationTimeouts),
 			"expiration_date": schema.SingleNestedBlock{
 				MarkdownDescription: "Configuration for the expiration date of the SSM activation. The total duration cannot exceed 30 days.",
 				Attributes: map[string]schema.Attribute{
@@ -195,6 +211,43 @@ func (r *ActivationResource) Schema(ctx context.Context, req resource.SchemaRequ
 	}
 }
 
+// ModifyPlan calcule l'attribut tags_all pendant le plan en fusionnant les tags de la ressource
+// avec les default_tags du provider. Une modification des tags effectifs, y compris lorsqu'elle
+// provient uniquement des default_tags, entraîne la recréation de l'activation comme pour tags.
+func (r *ActivationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Rien à calculer lors de la suppression
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ActivationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tagsAll, diags := tags.TagsAll(ctx, r.defaultTags, plan.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+
+	if req.State.Raw.IsNull() || tagsAll.IsUnknown() {
+		return
+	}
+
+	var state ActivationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.TagsAll.IsNull() && !tagsAll.Equal(state.TagsAll) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("tags_all"))
+	}
+}
+
 // Create crée une nouvelle activation SSM.
 // Cette méthode est appelée par Terraform lors de la création d'une ressource.
 // Elle valide la configuration, crée l'activation SSM et gère le stockage des secrets.
@@ -205,12 +258,26 @@ func (r *ActivationResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	// Utiliser les clients de la région de la ressource
+	conn := r.meta.SSMClient(data.Region.ValueString())
+	secrets := r.meta.SecretsManagerClient(data.Region.ValueString())
+
+	// Limiter la durée de l'opération au délai du bloc timeouts
+	createTimeout, diags := timeouts.Create(data.Timeouts, activationTimeouts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Normaliser les valeurs optionnelles immédiatement
 	r.normalizeOptionalValues(&data)
 
 	// Valider que le secret existe si managed = false
 	if !data.Managed.ValueBool() {
-		if diag := r.validateSecretExists(ctx, data.SecretName.ValueString()); diag.HasError() {
+		if diag := r.validateSecretExists(ctx, secrets, data.SecretName.ValueString()); diag.HasError() {
 			resp.Diagnostics.Append(diag...)
 			return
 		}
@@ -225,8 +292,15 @@ func (r *ActivationResource) Create(ctx context.Context, req resource.CreateRequ
 	// Calculer la date d'expiration
 	expirationDate := r.calculateExpirationDate(data.ExpirationDate, nil)
 
-	// Préparer les tags
-	tags, diag := r.convertTags(ctx, data.Tags)
+	// Préparer les tags (tags de la ressource fusionnés avec les default_tags du provider)
+	tagsAll, diags := tags.TagsAll(ctx, r.defaultTags, data.Tags)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	data.TagsAll = tagsAll
+
+	ssmTags, diag := r.convertTags(ctx, data.TagsAll)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
 		return
@@ -249,11 +323,11 @@ func (r *ActivationResource) Create(ctx context.Context, req resource.CreateRequ
 		createInput.ExpirationDate = expirationDate
 	}
 
-	if len(tags) > 0 {
-		createInput.Tags = tags
+	if len(ssmTags) > 0 {
+		createInput.Tags = ssmTags
 	}
 
-	createOutput, err := r.ssm.CreateActivation(ctx, createInput)
+	createOutput, err := conn.CreateActivation(ctx, createInput)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create SSM activation",
@@ -269,7 +343,7 @@ func (r *ActivationResource) Create(ctx context.Context, req resource.CreateRequ
 	data.Expired = types.BoolValue(false)
 
 	// Gérer le secret
-	if diag := r.manageSecret(ctx, &data, true); diag.HasError() {
+	if diag := r.manageSecret(ctx, secrets, &data, true); diag.HasError() {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
@@ -296,6 +370,10 @@ func (r *ActivationResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	// Utiliser les clients de la région de la ressource
+	conn := r.meta.SSMClient(data.Region.ValueString())
+	secrets := r.meta.SecretsManagerClient(data.Region.ValueString())
+
 	// Normaliser les valeurs optionnelles immédiatement
 	r.normalizeOptionalValues(&data)
 
@@ -309,7 +387,7 @@ func (r *ActivationResource) Read(ctx context.Context, req resource.ReadRequest,
 		},
 	}
 
-	describeOutput, err := r.ssm.DescribeActivations(ctx, describeInput)
+	describeOutput, err := conn.DescribeActivations(ctx, describeInput)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to retrieve SSM activation",
@@ -326,6 +404,16 @@ func (r *ActivationResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	activation := describeOutput.ActivationList[0]
 
+	// Les états créés avant l'ajout de tags_all ne contiennent pas encore la valeur fusionnée
+	if data.TagsAll.IsNull() || data.TagsAll.IsUnknown() {
+		tagsAll, diags := tags.TagsAll(ctx, r.defaultTags, data.Tags)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+		data.TagsAll = tagsAll
+	}
+
 	// Vérifier si l'activation a expiré
 	expired := activation.Expired
 	data.Expired = types.BoolValue(expired)
@@ -353,16 +441,16 @@ func (r *ActivationResource) Read(ctx context.Context, req resource.ReadRequest,
 		}
 
 		// Préparer les tags
-		tags, diag := r.convertTags(ctx, data.Tags)
+		ssmTags, diag := r.convertTags(ctx, data.TagsAll)
 		if diag.HasError() {
 			resp.Diagnostics.Append(diag...)
 			return
 		}
-		if len(tags) > 0 {
-			createInput.Tags = tags
+		if len(ssmTags) > 0 {
+			createInput.Tags = ssmTags
 		}
 
-		createOutput, err := r.ssm.CreateActivation(ctx, createInput)
+		createOutput, err := conn.CreateActivation(ctx, createInput)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to renew expired SSM activation",
@@ -378,7 +466,7 @@ func (r *ActivationResource) Read(ctx context.Context, req resource.ReadRequest,
 		data.Expired = types.BoolValue(false)
 
 		// Mettre à jour le secret
-		if diag := r.manageSecret(ctx, &data, false); diag.HasError() {
+		if diag := r.manageSecret(ctx, secrets, &data, false); diag.HasError() {
 			resp.Diagnostics.Append(diag...)
 			return
 		}
@@ -406,6 +494,20 @@ func (r *ActivationResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	// Utiliser les clients de la région de la ressource
+	conn := r.meta.SSMClient(data.Region.ValueString())
+	secrets := r.meta.SecretsManagerClient(data.Region.ValueString())
+
+	// Limiter la durée de l'opération au délai du bloc timeouts
+	updateTimeout, diags := timeouts.Update(data.Timeouts, activationTimeouts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Normaliser les valeurs optionnelles immédiatement
 	r.normalizeOptionalValues(&data)
 
@@ -436,8 +538,15 @@ func (r *ActivationResource) Update(ctx context.Context, req resource.UpdateRequ
 		needsRecreation = true
 	}
 
-	// Vérifier si les tags ont changé
-	if !data.Tags.Equal(currentData.Tags) {
+	// Recalculer les tags effectifs et vérifier si les tags ont changé
+	tagsAll, diags := tags.TagsAll(ctx, r.defaultTags, data.Tags)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	data.TagsAll = tagsAll
+
+	if !data.Tags.Equal(currentData.Tags) || !data.TagsAll.Equal(currentData.TagsAll) {
 		needsRecreation = true
 	}
 
@@ -453,14 +562,14 @@ func (r *ActivationResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	if needsRecreation {
 		// Supprimer l'ancienne activation
-		if diag := r.deleteActivation(ctx, currentData.ActivationId.ValueString()); diag.HasError() {
+		if diag := r.deleteActivation(ctx, conn, currentData.ActivationId.ValueString()); diag.HasError() {
 			resp.Diagnostics.Append(diag...)
 			return
 		}
 
 		// Supprimer l'ancien secret si géré
 		if !currentData.SecretName.IsNull() && currentData.Managed.ValueBool() {
-			if diag := r.deleteSecret(ctx, currentData.SecretName.ValueString()); diag.HasError() {
+			if diag := r.deleteSecret(ctx, secrets, currentData.SecretName.ValueString()); diag.HasError() {
 				resp.Diagnostics.Append(diag...)
 				return
 			}
@@ -469,7 +578,7 @@ func (r *ActivationResource) Update(ctx context.Context, req resource.UpdateRequ
 		// Créer une nouvelle activation
 		// Valider que le secret existe si managed = false
 		if !data.Managed.ValueBool() {
-			if diag := r.validateSecretExists(ctx, data.SecretName.ValueString()); diag.HasError() {
+			if diag := r.validateSecretExists(ctx, secrets, data.SecretName.ValueString()); diag.HasError() {
 				resp.Diagnostics.Append(diag...)
 				return
 			}
@@ -485,7 +594,7 @@ func (r *ActivationResource) Update(ctx context.Context, req resource.UpdateRequ
 		expirationDate := r.calculateExpirationDate(data.ExpirationDate, nil)
 
 		// Préparer les tags
-		tags, diag := r.convertTags(ctx, data.Tags)
+		ssmTags, diag := r.convertTags(ctx, data.TagsAll)
 		if diag.HasError() {
 			resp.Diagnostics.Append(diag...)
 			return
@@ -508,11 +617,11 @@ func (r *ActivationResource) Update(ctx context.Context, req resource.UpdateRequ
 			createInput.ExpirationDate = expirationDate
 		}
 
-		if len(tags) > 0 {
-			createInput.Tags = tags
+		if len(ssmTags) > 0 {
+			createInput.Tags = ssmTags
 		}
 
-		createOutput, err := r.ssm.CreateActivation(ctx, createInput)
+		createOutput, err := conn.CreateActivation(ctx, createInput)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to create new SSM activation",
@@ -528,7 +637,7 @@ func (r *ActivationResource) Update(ctx context.Context, req resource.UpdateRequ
 		data.Expired = types.BoolValue(false)
 
 		// Gérer le secret
-		if diag := r.manageSecret(ctx, &data, true); diag.HasError() {
+		if diag := r.manageSecret(ctx, secrets, &data, true); diag.HasError() {
 			resp.Diagnostics.Append(diag...)
 			return
 		}
@@ -537,7 +646,7 @@ func (r *ActivationResource) Update(ctx context.Context, req resource.UpdateRequ
 	} else {
 		// Seuls secret_name et managed peuvent être modifiés sans recréation
 		// Mettre à jour le secret
-		if diag := r.manageSecret(ctx, &data, false); diag.HasError() {
+		if diag := r.manageSecret(ctx, secrets, &data, false); diag.HasError() {
 			resp.Diagnostics.Append(diag...)
 			return
 		}
@@ -570,15 +679,29 @@ func (r *ActivationResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	// Utiliser les clients de la région de la ressource
+	conn := r.meta.SSMClient(data.Region.ValueString())
+	secrets := r.meta.SecretsManagerClient(data.Region.ValueString())
+
+	// Limiter la durée de l'opération au délai du bloc timeouts
+	deleteTimeout, diags := timeouts.Delete(data.Timeouts, activationTimeouts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Supprimer l'activation SSM
-	if diag := r.deleteActivation(ctx, data.ActivationId.ValueString()); diag.HasError() {
+	if diag := r.deleteActivation(ctx, conn, data.ActivationId.ValueString()); diag.HasError() {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
 	// Supprimer le secret si géré
 	if data.Managed.ValueBool() {
-		if diag := r.deleteSecret(ctx, data.SecretName.ValueString()); diag.HasError() {
+		if diag := r.deleteSecret(ctx, secrets, data.SecretName.ValueString()); diag.HasError() {
 			resp.Diagnostics.Append(diag...)
 			return
 		}
@@ -689,32 +812,48 @@ func (r *ActivationResource) expirationDateEqual(a, b *ExpirationDateModel) bool
 }
 
 // convertTags convertit les tags Terraform en format attendu par l'API SSM.
-func (r *ActivationResource) convertTags(ctx context.Context, tags types.Map) ([]ssmtypes.Tag, diag.Diagnostics) {
+// Les tags sont triés par clé afin que les appels à l'API soient déterministes.
+func (r *ActivationResource) convertTags(ctx context.Context, tagsAll types.Map) ([]ssmtypes.Tag, diag.Diagnostics) {
 	var diagnostics diag.Diagnostics
 
-	if tags.IsNull() || tags.IsUnknown() {
+	if tagsAll.IsNull() || tagsAll.IsUnknown() {
 		return []ssmtypes.Tag{}, diagnostics
 	}
 
 	tagMap := make(map[string]string)
-	diagnostics.Append(tags.ElementsAs(ctx, &tagMap, false)...)
+	diagnostics.Append(tagsAll.ElementsAs(ctx, &tagMap, false)...)
 	if diagnostics.HasError() {
 		return nil, diagnostics
 	}
 
 	ssmTags := make([]ssmtypes.Tag, 0, len(tagMap))
-	for key, value := range tagMap {
+	for _, key := range tags.Keys(tagMap) {
 		ssmTags = append(ssmTags, ssmtypes.Tag{
 			Key:   aws.String(key),
-			Value: aws.String(value),
+			Value: aws.String(tagMap[key]),
 		})
 	}
 
 	return ssmTags, diagnostics
 }
 
+// convertSecretTags convertit les tags Terraform en format attendu par l'API Secrets Manager.
+func (r *ActivationResource) convertSecretTags(ctx context.Context, tagsAll types.Map) ([]secretsmanagertypes.Tag, diag.Diagnostics) {
+	ssmTags, diagnostics := r.convertTags(ctx, tagsAll)
+
+	secretTags := make([]secretsmanagertypes.Tag, 0, len(ssmTags))
+	for _, tag := range ssmTags {
+		secretTags = append(secretTags, secretsmanagertypes.Tag{
+			Key:   tag.Key,
+			Value: tag.Value,
+		})
+	}
+
+	return secretTags, diagnostics
+}
+
 // manageSecret gère la création, mise à jour ou suppression du secret.
-func (r *ActivationResource) manageSecret(ctx context.Context, data *ActivationResourceModel, isCreate bool) diag.Diagnostics {
+func (r *ActivationResource) manageSecret(ctx context.Context, secrets *secretsmanager.Client, data *ActivationResourceModel, isCreate bool) diag.Diagnostics {
 	var diagnostics diag.Diagnostics
 
 	secretName := data.SecretName.ValueString()
@@ -737,19 +876,28 @@ func (r *ActivationResource) manageSecret(ctx context.Context, data *ActivationR
 
 	if isCreate && managed {
 		// Vérifier si le secret existe déjà
-		_, err := r.secretsManager.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+		_, err := secrets.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
 			SecretId: aws.String(secretName),
 		})
 		
 		if err != nil {
-			// Le secret n'existe pas, le créer
+			// Le secret n'existe pas, le créer avec les mêmes tags que l'activation
+			secretTags, diags := r.convertSecretTags(ctx, data.TagsAll)
+			if diags.HasError() {
+				diagnostics.Append(diags...)
+				return diagnostics
+			}
+
 			createInput := &secretsmanager.CreateSecretInput{
 				Name:         aws.String(secretName),
 				SecretString: aws.String(string(secretJSON)),
 				Description:  aws.String("SSM Activation data managed by terraform-provider-test"),
 			}
+			if len(secretTags) > 0 {
+				createInput.Tags = secretTags
+			}
 
-			createOutput, err := r.secretsManager.CreateSecret(ctx, createInput)
+			createOutput, err := secrets.CreateSecret(ctx, createInput)
 			if err != nil {
 				diagnostics.AddError(
 					"Unable to create secret in Secrets Manager",
@@ -769,7 +917,7 @@ func (r *ActivationResource) manageSecret(ctx context.Context, data *ActivationR
 				SecretString: aws.String(string(secretJSON)),
 			}
 
-			putOutput, err := r.secretsManager.PutSecretValue(ctx, putInput)
+			putOutput, err := secrets.PutSecretValue(ctx, putInput)
 			if err != nil {
 				diagnostics.AddError(
 					"Unable to update secret in Secrets Manager",
@@ -779,7 +927,7 @@ func (r *ActivationResource) manageSecret(ctx context.Context, data *ActivationR
 			}
 
 			// Récupérer les métadonnées du secret
-			describeOutput, err := r.secretsManager.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+			describeOutput, err := secrets.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
 				SecretId: aws.String(secretName),
 			})
 			if err != nil {
@@ -802,7 +950,7 @@ func (r *ActivationResource) manageSecret(ctx context.Context, data *ActivationR
 			SecretString: aws.String(string(secretJSON)),
 		}
 
-		putOutput, err := r.secretsManager.PutSecretValue(ctx, putInput)
+		putOutput, err := secrets.PutSecretValue(ctx, putInput)
 		if err != nil {
 			diagnostics.AddError(
 				"Unable to update secret in Secrets Manager",
@@ -812,7 +960,7 @@ func (r *ActivationResource) manageSecret(ctx context.Context, data *ActivationR
 		}
 
 		// Récupérer les métadonnées du secret
-		describeOutput, err := r.secretsManager.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+		describeOutput, err := secrets.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
 			SecretId: aws.String(secretName),
 		})
 		if err != nil {
@@ -833,14 +981,14 @@ func (r *ActivationResource) manageSecret(ctx context.Context, data *ActivationR
 }
 
 // deleteActivation supprime une activation SSM.
-func (r *ActivationResource) deleteActivation(ctx context.Context, activationId string) diag.Diagnostics {
+func (r *ActivationResource) deleteActivation(ctx context.Context, conn *ssm.Client, activationId string) diag.Diagnostics {
 	var diagnostics diag.Diagnostics
 
 	deleteInput := &ssm.DeleteActivationInput{
 		ActivationId: aws.String(activationId),
 	}
 
-	_, err := r.ssm.DeleteActivation(ctx, deleteInput)
+	_, err := conn.DeleteActivation(ctx, deleteInput)
 	if err != nil {
 		diagnostics.AddError(
 			"Unable to delete SSM activation",
@@ -853,7 +1001,7 @@ func (r *ActivationResource) deleteActivation(ctx context.Context, activationId
 }
 
 // deleteSecret supprime un secret AWS Secrets Manager.
-func (r *ActivationResource) deleteSecret(ctx context.Context, secretName string) diag.Diagnostics {
+func (r *ActivationResource) deleteSecret(ctx context.Context, secrets *secretsmanager.Client, secretName string) diag.Diagnostics {
 	var diagnostics diag.Diagnostics
 
 	deleteInput := &secretsmanager.DeleteSecretInput{
@@ -861,7 +1009,7 @@ func (r *ActivationResource) deleteSecret(ctx context.Context, secretName string
 		ForceDeleteWithoutRecovery: aws.Bool(true),
 	}
 
-	_, err := r.secretsManager.DeleteSecret(ctx, deleteInput)
+	_, err := secrets.DeleteSecret(ctx, deleteInput)
 	if err != nil {
 		diagnostics.AddError(
 			"Unable to delete secret from Secrets Manager",
@@ -874,10 +1022,10 @@ func (r *ActivationResource) deleteSecret(ctx context.Context, secretName string
 }
 
 // validateSecretExists vérifie qu'un secret existe dans AWS Secrets Manager.
-func (r *ActivationResource) validateSecretExists(ctx context.Context, secretName string) diag.Diagnostics {
+func (r *ActivationResource) validateSecretExists(ctx context.Context, secrets *secretsmanager.Client, secretName string) diag.Diagnostics {
 	var diagnostics diag.Diagnostics
 
-	_, err := r.secretsManager.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+	_, err := secrets.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
 		SecretId: aws.String(secretName),
 	})
 	if err != nil {
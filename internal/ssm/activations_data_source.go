@@ -13,6 +13,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+	"github.com/jd-ucpa/terraform-provider-test/internal/region"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -29,13 +31,14 @@ func NewActivationsDataSource() datasource.DataSource {
 // Ce data source permet de récupérer les détails de plusieurs activations SSM
 // en utilisant des filtres et en gérant la pagination.
 type ActivationsDataSource struct {
-	ssm *ssm.Client
+	meta *conns.ProviderMeta
 }
 
 // ActivationsDataSourceModel définit le modèle de données pour le data source Activations.
 // Il contient tous les attributs de configuration et les données retournées par l'API SSM.
 type ActivationsDataSourceModel struct {
 	Id         types.String           `tfsdk:"id"`
+	Region     types.String           `tfsdk:"region"`
 	Expired    types.Bool             `tfsdk:"expired"`
 	Filters    []FilterModel          `tfsdk:"filter"`
 	Activations []ActivationModel     `tfsdk:"activations"`
@@ -96,23 +99,14 @@ func (d *ActivationsDataSource) Metadata(ctx context.Context, req datasource.Met
 // Cette méthode est appelée par Terraform pour configurer le data source avec
 // les paramètres d'authentification AWS (région, credentials, etc.).
 func (d *ActivationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	// Éviter le panic si le provider n'a pas été configuré
-	if req.ProviderData == nil {
+	meta, diags := conns.FromProviderData(req.ProviderData, "SSM activations data source")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
 		return
 	}
 
-	// Vérifier que la configuration est du bon type
-	config, ok := req.ProviderData.(aws.Config)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Provider configuration error",
-			fmt.Sprintf("Expected aws.Config for SSM activations data source, got: %T. This indicates a provider configuration issue. Please verify your provider configuration and report this issue if it persists.", req.ProviderData),
-		)
-		return
-	}
-	
-	// Créer le client SSM à partir de la configuration AWS
-	d.ssm = ssm.NewFromConfig(config)
+	// Conserver la configuration du provider pour créer les clients de la région demandée
+	d.meta = meta
 }
 
 // Schema définit la structure et la documentation du data source.
@@ -126,6 +120,7 @@ func (d *ActivationsDataSource) Schema(ctx context.Context, req datasource.Schem
 				Computed:            true,
 				MarkdownDescription: "The ID of the data source (always 'ssm_activations').",
 			},
+			"region": region.DataSourceAttribute(),
 			"expired": schema.BoolAttribute{
 				Optional:            true,
 				MarkdownDescription: "Filter activations by expiration status. If not specified, returns both expired and non-expired activations.",
@@ -201,6 +196,9 @@ func (d *ActivationsDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
+	// Utiliser le client de la région de la configuration
+	conn := d.meta.SSMClient(data.Region.ValueString())
+
 	// Construire les filtres pour l'API SSM
 	filters, diag := d.buildFilters(data.Filters)
 	if diag.HasError() {
@@ -224,7 +222,7 @@ func (d *ActivationsDataSource) Read(ctx context.Context, req datasource.ReadReq
 		}
 
 		// Appeler l'API SSM DescribeActivations
-		output, err := d.ssm.DescribeActivations(ctx, input)
+		output, err := conn.DescribeActivations(ctx, input)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to retrieve SSM activations",
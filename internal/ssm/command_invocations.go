@@ -0,0 +1,346 @@
+package ssm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// CommandInvocationModel définit le modèle d'une invocation de la commande sur une instance,
+// exposé dans l'attribut calculé invocations.
+type CommandInvocationModel struct {
+	InstanceId              types.String         `tfsdk:"instance_id"`
+	Status                  types.String         `tfsdk:"status"`
+	StatusDetails           types.String         `tfsdk:"status_details"`
+	ResponseCode            types.Int64          `tfsdk:"response_code"`
+	StandardOutput          types.String         `tfsdk:"standard_output"`
+	StandardError           types.String         `tfsdk:"standard_error"`
+	ExecutionStartDateTime  types.String         `tfsdk:"execution_start_date_time"`
+	ExecutionEndDateTime    types.String         `tfsdk:"execution_end_date_time"`
+	StandardOutputUrl       types.String         `tfsdk:"standard_output_url"`
+	StandardErrorUrl        types.String         `tfsdk:"standard_error_url"`
+	CloudWatchLogGroupName  types.String         `tfsdk:"cloudwatch_log_group_name"`
+	StandardOutputLogStream types.String         `tfsdk:"standard_output_log_stream"`
+	StandardErrorLogStream  types.String         `tfsdk:"standard_error_log_stream"`
+	Plugins                 []CommandPluginModel `tfsdk:"plugins"`
+}
+
+// CommandPluginModel définit le modèle d'une étape (plugin) du document exécutée sur une instance.
+type CommandPluginModel struct {
+	Name                    types.String `tfsdk:"name"`
+	Status                  types.String `tfsdk:"status"`
+	StatusDetails           types.String `tfsdk:"status_details"`
+	ResponseCode            types.Int64  `tfsdk:"response_code"`
+	Output                  types.String `tfsdk:"output"`
+	StandardOutputUrl       types.String `tfsdk:"standard_output_url"`
+	StandardErrorUrl        types.String `tfsdk:"standard_error_url"`
+	StandardOutputLogStream types.String `tfsdk:"standard_output_log_stream"`
+	StandardErrorLogStream  types.String `tfsdk:"standard_error_log_stream"`
+}
+
+// commandPluginAttrTypes définit les types des attributs d'un élément de plugins.
+var commandPluginAttrTypes = map[string]attr.Type{
+	"name":                       types.StringType,
+	"status":                     types.StringType,
+	"status_details":             types.StringType,
+	"response_code":              types.Int64Type,
+	"output":                     types.StringType,
+	"standard_output_url":        types.StringType,
+	"standard_error_url":         types.StringType,
+	"standard_output_log_stream": types.StringType,
+	"standard_error_log_stream":  types.StringType,
+}
+
+// commandInvocationAttrTypes définit les types des attributs d'un élément de invocations.
+var commandInvocationAttrTypes = map[string]attr.Type{
+	"instance_id":                types.StringType,
+	"status":                     types.StringType,
+	"status_details":             types.StringType,
+	"response_code":              types.Int64Type,
+	"standard_output":            types.StringType,
+	"standard_error":             types.StringType,
+	"execution_start_date_time":  types.StringType,
+	"execution_end_date_time":    types.StringType,
+	"standard_output_url":        types.StringType,
+	"standard_error_url":         types.StringType,
+	"cloudwatch_log_group_name":  types.StringType,
+	"standard_output_log_stream": types.StringType,
+	"standard_error_log_stream":  types.StringType,
+	"plugins":                    types.ListType{ElemType: types.ObjectType{AttrTypes: commandPluginAttrTypes}},
+}
+
+// invocationsAttribute retourne l'attribut calculé invocations, qui détaille le résultat
+// de la commande sur chaque instance ciblée.
+func invocationsAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: "The result of the command on each targeted instance. Refreshed from SSM together with `status`.",
+		PlanModifiers: []planmodifier.List{
+			listplanmodifier.UseStateForUnknown(),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"instance_id": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The ID of the instance.",
+				},
+				"status": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The status of the command on the instance (Pending, InProgress, Delayed, Success, Cancelled, TimedOut, Failed or Cancelling).",
+				},
+				"status_details": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "A detailed status of the command on the instance, e.g. `Undeliverable` or `ExecutionTimedOut`.",
+				},
+				"response_code": schema.Int64Attribute{
+					Computed:            true,
+					MarkdownDescription: "The exit code of the command on the instance: the first non-zero exit code of the document steps, `0` if all of them succeeded, or `-1` if no step has run yet.",
+				},
+				"standard_output": schema.StringAttribute{
+					Computed:            true,
+					Sensitive:           true,
+					MarkdownDescription: fmt.Sprintf("The standard output of the command on the instance, truncated by SSM to 24,000 characters. For documents with several steps, the outputs of the steps are concatenated. Only read once the command has completed on the instance, for at most %d steps across all instances: `null` otherwise, see `plugins` for an excerpt. Marked as sensitive since it may contain secrets.", maxCommandOutputReads),
+				},
+				"standard_error": schema.StringAttribute{
+					Computed:            true,
+					Sensitive:           true,
+					MarkdownDescription: "The standard error of the command on the instance, truncated by SSM to 8,000 characters. For documents with several steps, the errors of the steps are concatenated. Read under the same conditions as `standard_output`. Marked as sensitive since it may contain secrets.",
+				},
+				"execution_start_date_time": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The date and time when the command started on the instance, in ISO 8601 format.",
+				},
+				"execution_end_date_time": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The date and time when the command completed on the instance, in ISO 8601 format.",
+				},
+				"standard_output_url": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The URL of the complete standard output of the command in S3, when `output_s3_bucket_name` is set. As with SSM, it is only set for documents with a single step: see `plugins` otherwise.",
+				},
+				"standard_error_url": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The URL of the complete standard error of the command in S3, when `output_s3_bucket_name` is set. As with SSM, it is only set for documents with a single step: see `plugins` otherwise.",
+				},
+				"cloudwatch_log_group_name": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The CloudWatch Logs log group of the output of the command, when `cloudwatch_output_config` is enabled.",
+				},
+				"standard_output_log_stream": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The CloudWatch Logs log stream of the complete standard output of the command, when `cloudwatch_output_config` is enabled. Only set for documents with a single step: see `plugins` otherwise.",
+				},
+				"standard_error_log_stream": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The CloudWatch Logs log stream of the complete standard error of the command, when `cloudwatch_output_config` is enabled. Only set for documents with a single step: see `plugins` otherwise.",
+				},
+				"plugins": schema.ListNestedAttribute{
+					Computed:            true,
+					MarkdownDescription: "The result of each step (plugin) of the document on the instance.",
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"name": schema.StringAttribute{
+								Computed:            true,
+								MarkdownDescription: "The name of the step, e.g. `aws:runShellScript`.",
+							},
+							"status": schema.StringAttribute{
+								Computed:            true,
+								MarkdownDescription: "The status of the step.",
+							},
+							"status_details": schema.StringAttribute{
+								Computed:            true,
+								MarkdownDescription: "A detailed status of the step.",
+							},
+							"response_code": schema.Int64Attribute{
+								Computed:            true,
+								MarkdownDescription: "The exit code of the step, or `-1` if it has not run yet.",
+							},
+							"output": schema.StringAttribute{
+								Computed:            true,
+								Sensitive:           true,
+								MarkdownDescription: "The output of the step, truncated by SSM to 2,500 characters. Marked as sensitive since it may contain secrets.",
+							},
+							"standard_output_url": schema.StringAttribute{
+								Computed:            true,
+								MarkdownDescription: "The URL of the complete standard output of the step in S3, when `output_s3_bucket_name` is set.",
+							},
+							"standard_error_url": schema.StringAttribute{
+								Computed:            true,
+								MarkdownDescription: "The URL of the complete standard error of the step in S3, when `output_s3_bucket_name` is set.",
+							},
+							"standard_output_log_stream": schema.StringAttribute{
+								Computed:            true,
+								MarkdownDescription: "The CloudWatch Logs log stream of the complete standard output of the step, when `cloudwatch_output_config` is enabled.",
+							},
+							"standard_error_log_stream": schema.StringAttribute{
+								Computed:            true,
+								MarkdownDescription: "The CloudWatch Logs log stream of the complete standard error of the step, when `cloudwatch_output_config` is enabled.",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// invocationsNull retourne la valeur nulle de l'attribut invocations.
+func invocationsNull() types.List {
+	return types.ListNull(types.ObjectType{AttrTypes: commandInvocationAttrTypes})
+}
+
+// maxCommandOutputReads est le nombre maximal d'appels à GetCommandInvocation lors d'une lecture
+// des invocations d'une commande. Il limite le nombre d'appels à l'API pour les commandes qui
+// ciblent de nombreuses instances.
+const maxCommandOutputReads = 20
+
+// invocationsValue construit la valeur de l'attribut invocations à partir des invocations
+// d'une commande. Le statut, le code de retour et l'extrait de la sortie de chaque étape sont
+// lus dans le résultat de ListCommandInvocations. Les sorties standard et d'erreur ne sont
+// lues avec GetCommandInvocation que pour les invocations terminées, dans la limite de
+// maxCommandOutputReads appels.
+func invocationsValue(ctx context.Context, client *ssm.Client, commandId string, invocations []ssmtypes.CommandInvocation) (types.List, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	reads := maxCommandOutputReads
+	models := make([]CommandInvocationModel, 0, len(invocations))
+	for _, invocation := range invocations {
+		model, err := commandInvocationModel(ctx, client, commandId, invocation, &reads)
+		if err != nil {
+			diagnostics.AddError(
+				"Unable to retrieve command invocation details",
+				fmt.Sprintf("Error while reading the result of command '%s' on instance '%s': %s. Please verify your AWS credentials and permissions.", commandId, aws.ToString(invocation.InstanceId), err),
+			)
+			return invocationsNull(), diagnostics
+		}
+		models = append(models, model)
+	}
+
+	if reads < 0 {
+		tflog.Warn(ctx, "Standard output and error not read for all command invocations", map[string]any{
+			"command_id": commandId,
+			"max_reads":  maxCommandOutputReads,
+		})
+	}
+
+	value, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: commandInvocationAttrTypes}, models)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return invocationsNull(), diagnostics
+	}
+
+	return value, diagnostics
+}
+
+// commandInvocationModel construit le résultat de la commande sur une instance. Les sorties
+// standard et d'erreur restent nulles si l'invocation n'est pas terminée ou si le nombre
+// d'appels restant (reads) ne suffit pas à lire toutes ses étapes ; reads devient alors négatif.
+// Les étapes qui n'ont pas démarré n'ont pas de sortie à lire.
+func commandInvocationModel(ctx context.Context, client *ssm.Client, commandId string, invocation ssmtypes.CommandInvocation, reads *int) (CommandInvocationModel, error) {
+	model := CommandInvocationModel{
+		InstanceId:              types.StringValue(aws.ToString(invocation.InstanceId)),
+		Status:                  types.StringValue(string(invocation.Status)),
+		StatusDetails:           types.StringValue(aws.ToString(invocation.StatusDetails)),
+		ResponseCode:            types.Int64Value(-1),
+		StandardOutput:          types.StringNull(),
+		StandardError:           types.StringNull(),
+		ExecutionStartDateTime:  types.StringNull(),
+		ExecutionEndDateTime:    types.StringNull(),
+		StandardOutputUrl:       types.StringValue(aws.ToString(invocation.StandardOutputUrl)),
+		StandardErrorUrl:        types.StringValue(aws.ToString(invocation.StandardErrorUrl)),
+		CloudWatchLogGroupName:  types.StringValue(""),
+		StandardOutputLogStream: types.StringValue(""),
+		StandardErrorLogStream:  types.StringValue(""),
+		Plugins:                 make([]CommandPluginModel, 0, len(invocation.CommandPlugins)),
+	}
+
+	// Comme pour les URL S3, les log streams de l'invocation ne sont renseignés que pour
+	// les documents à une seule étape
+	if invocation.CloudWatchOutputConfig != nil && invocation.CloudWatchOutputConfig.CloudWatchOutputEnabled {
+		model.CloudWatchLogGroupName = types.StringValue(aws.ToString(invocation.CloudWatchOutputConfig.CloudWatchLogGroupName))
+	}
+	if len(invocation.CommandPlugins) == 1 {
+		pluginName := aws.ToString(invocation.CommandPlugins[0].Name)
+		model.StandardOutputLogStream = types.StringValue(cloudWatchLogStream(commandId, invocation, pluginName, "stdout"))
+		model.StandardErrorLogStream = types.StringValue(cloudWatchLogStream(commandId, invocation, pluginName, "stderr"))
+	}
+
+	var executed []ssmtypes.CommandPlugin
+	for _, plugin := range invocation.CommandPlugins {
+		model.Plugins = append(model.Plugins, CommandPluginModel{
+			Name:                    types.StringValue(aws.ToString(plugin.Name)),
+			Status:                  types.StringValue(string(plugin.Status)),
+			StatusDetails:           types.StringValue(aws.ToString(plugin.StatusDetails)),
+			ResponseCode:            types.Int64Value(int64(plugin.ResponseCode)),
+			Output:                  types.StringValue(aws.ToString(plugin.Output)),
+			StandardOutputUrl:       types.StringValue(aws.ToString(plugin.StandardOutputUrl)),
+			StandardErrorUrl:        types.StringValue(aws.ToString(plugin.StandardErrorUrl)),
+			StandardOutputLogStream: types.StringValue(cloudWatchLogStream(commandId, invocation, aws.ToString(plugin.Name), "stdout")),
+			StandardErrorLogStream:  types.StringValue(cloudWatchLogStream(commandId, invocation, aws.ToString(plugin.Name), "stderr")),
+		})
+
+		if plugin.Status == ssmtypes.CommandPluginStatusPending {
+			continue
+		}
+		executed = append(executed, plugin)
+
+		// Conserver le premier code de retour non nul des étapes exécutées
+		if code := int64(plugin.ResponseCode); model.ResponseCode.ValueInt64() == -1 || (model.ResponseCode.ValueInt64() == 0 && code > 0) {
+			model.ResponseCode = types.Int64Value(code)
+		}
+
+		// La commande démarre avec sa première étape et se termine avec la dernière
+		if plugin.ResponseStartDateTime != nil && model.ExecutionStartDateTime.IsNull() {
+			model.ExecutionStartDateTime = types.StringValue(plugin.ResponseStartDateTime.Format(time.RFC3339))
+		}
+		if plugin.ResponseFinishDateTime != nil {
+			model.ExecutionEndDateTime = types.StringValue(plugin.ResponseFinishDateTime.Format(time.RFC3339))
+		}
+	}
+
+	// La sortie d'une invocation en cours évolue encore : elle sera lue une fois terminée
+	if !commandDone(string(invocation.Status)) {
+		return model, nil
+	}
+	if *reads < len(executed) {
+		*reads = -1
+		return model, nil
+	}
+
+	var standardOutputs, standardErrors []string
+	for _, plugin := range executed {
+		*reads--
+		output, err := client.GetCommandInvocation(ctx, &ssm.GetCommandInvocationInput{
+			CommandId:  aws.String(commandId),
+			InstanceId: invocation.InstanceId,
+			PluginName: plugin.Name,
+		})
+		if err != nil {
+			return model, fmt.Errorf("calling AWS SSM GetCommandInvocation API for step '%s': %w", aws.ToString(plugin.Name), err)
+		}
+
+		if content := aws.ToString(output.StandardOutputContent); content != "" {
+			standardOutputs = append(standardOutputs, content)
+		}
+		if content := aws.ToString(output.StandardErrorContent); content != "" {
+			standardErrors = append(standardErrors, content)
+		}
+	}
+
+	model.StandardOutput = types.StringValue(strings.Join(standardOutputs, "\n"))
+	model.StandardError = types.StringValue(strings.Join(standardErrors, "\n"))
+
+	return model, nil
+}
@@ -0,0 +1,188 @@
+package ssm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/jd-ucpa/terraform-provider-test/internal/partition"
+)
+
+// Limites de l'attribut timeout_seconds, telles que définies par l'API SendCommand.
+const (
+	minCommandTimeoutSeconds = 30
+	maxCommandTimeoutSeconds = 2592000
+)
+
+// Formats acceptés par l'API SendCommand pour les options d'exécution de la commande.
+var (
+	maxConcurrencyRegexp  = regexp.MustCompile(`^([1-9][0-9]*|[1-9][0-9]%|[1-9]%|100%)$`)
+	maxErrorsRegexp       = regexp.MustCompile(`^([1-9][0-9]*|[0-9]|[1-9][0-9]%|[0-9]%|100%)$`)
+	documentVersionRegexp = regexp.MustCompile(`^(\$DEFAULT|\$LATEST|[1-9][0-9]*)$`)
+	documentHashRegexp    = regexp.MustCompile(`^[a-fA-F0-9]{64}$`)
+)
+
+// notificationEvents liste les valeurs acceptées par notification_config.notification_events.
+var notificationEvents = []string{
+	string(ssmtypes.NotificationEventAll),
+	string(ssmtypes.NotificationEventInProgress),
+	string(ssmtypes.NotificationEventSuccess),
+	string(ssmtypes.NotificationEventTimedOut),
+	string(ssmtypes.NotificationEventCancelled),
+	string(ssmtypes.NotificationEventFailed),
+}
+
+// notificationTypes liste les valeurs acceptées par notification_config.notification_type.
+var notificationTypes = []string{
+	string(ssmtypes.NotificationTypeCommand),
+	string(ssmtypes.NotificationTypeInvocation),
+}
+
+// NotificationConfigModel définit le modèle pour le bloc notification_config de la ressource.
+type NotificationConfigModel struct {
+	NotificationArn    types.String `tfsdk:"notification_arn"`
+	NotificationEvents types.List   `tfsdk:"notification_events"`
+	NotificationType   types.String `tfsdk:"notification_type"`
+}
+
+// notificationConfigBlock retourne le bloc notification_config, qui publie les changements de
+// statut de la commande sur un topic SNS. Les attributs sont optionnels pour que le bloc puisse
+// être omis : la présence de notification_arn est vérifiée par validateNotificationConfig.
+func notificationConfigBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Publishes the status changes of the command to an Amazon SNS topic. Requires `service_role_arn`.",
+		Attributes: map[string]schema.Attribute{
+			"notification_arn": schema.StringAttribute{
+				MarkdownDescription: "The ARN of the SNS topic to publish notifications to. Required when the block is set.",
+				Optional:            true,
+				Validators: []validator.String{
+					partition.ARNValidator("sns"),
+				},
+			},
+			"notification_events": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: fmt.Sprintf("The status changes to publish notifications for (%s). Defaults to `All`.", strings.Join(notificationEvents, ", ")),
+				Optional:            true,
+				Validators: []validator.List{
+					oneOfValidator{attribute: "notification_events", values: notificationEvents},
+				},
+			},
+			"notification_type": schema.StringAttribute{
+				MarkdownDescription: "`Command` publishes the status changes of the command as a whole, `Invocation` the status changes of the command on each instance. Defaults to `Command`.",
+				Optional:            true,
+				Validators: []validator.String{
+					oneOfValidator{attribute: "notification_type", values: notificationTypes},
+				},
+			},
+		},
+	}
+}
+
+// validateNotificationConfig vérifie au moment du plan que le bloc notification_config, s'il est
+// défini, indique un topic SNS et qu'un rôle de service lui permet de publier les notifications.
+// Les valeurs encore inconnues seront vérifiées au plan suivant.
+func validateNotificationConfig(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	var notification types.Object
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("notification_config"), &notification)...)
+	if diagnostics.HasError() || notification.IsNull() || notification.IsUnknown() {
+		return diagnostics
+	}
+
+	var model NotificationConfigModel
+	diagnostics.Append(notification.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	if model.NotificationArn.IsNull() {
+		diagnostics.AddAttributeError(
+			path.Root("notification_config").AtName("notification_arn"),
+			"Missing notification_arn",
+			"notification_arn must be set in the notification_config block.",
+		)
+	}
+
+	var serviceRoleArn types.String
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("service_role_arn"), &serviceRoleArn)...)
+	if serviceRoleArn.IsNull() {
+		diagnostics.AddAttributeError(
+			path.Root("service_role_arn"),
+			"Missing service_role_arn",
+			"service_role_arn must be set when notification_config is set: SSM uses this role to publish notifications to the SNS topic.",
+		)
+	}
+
+	return diagnostics
+}
+
+// notificationConfig construit la configuration de notification SNS de l'API SendCommand.
+// Elle retourne nil si le bloc notification_config n'est pas défini.
+func notificationConfig(ctx context.Context, model *NotificationConfigModel) (*ssmtypes.NotificationConfig, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	if model == nil {
+		return nil, diagnostics
+	}
+
+	config := &ssmtypes.NotificationConfig{
+		NotificationArn:    model.NotificationArn.ValueStringPointer(),
+		NotificationEvents: []ssmtypes.NotificationEvent{ssmtypes.NotificationEventAll},
+		NotificationType:   ssmtypes.NotificationTypeCommand,
+	}
+
+	if !model.NotificationEvents.IsNull() {
+		var events []string
+		diagnostics.Append(model.NotificationEvents.ElementsAs(ctx, &events, false)...)
+		if diagnostics.HasError() {
+			return nil, diagnostics
+		}
+
+		config.NotificationEvents = make([]ssmtypes.NotificationEvent, len(events))
+		for i, event := range events {
+			config.NotificationEvents[i] = ssmtypes.NotificationEvent(event)
+		}
+	}
+
+	if !model.NotificationType.IsNull() {
+		config.NotificationType = ssmtypes.NotificationType(model.NotificationType.ValueString())
+	}
+
+	return config, diagnostics
+}
+
+// applyExecutionOptions reporte les options d'exécution de la commande (contrôle du débit,
+// version du document, rôle de service, notifications) sur l'entrée de l'API SendCommand.
+// Les attributs non définis ne sont pas envoyés et prennent la valeur par défaut de SSM.
+func applyExecutionOptions(ctx context.Context, input *ssm.SendCommandInput, data SendCommandResourceModel) diag.Diagnostics {
+	input.MaxConcurrency = data.MaxConcurrency.ValueStringPointer()
+	input.MaxErrors = data.MaxErrors.ValueStringPointer()
+	input.DocumentVersion = data.DocumentVersion.ValueStringPointer()
+	input.ServiceRoleArn = data.ServiceRoleArn.ValueStringPointer()
+
+	if !data.TimeoutSeconds.IsNull() {
+		input.TimeoutSeconds = aws.Int32(int32(data.TimeoutSeconds.ValueInt64()))
+	}
+
+	if !data.DocumentHash.IsNull() {
+		input.DocumentHash = data.DocumentHash.ValueStringPointer()
+		input.DocumentHashType = ssmtypes.DocumentHashTypeSha256
+	}
+
+	config, diags := notificationConfig(ctx, data.NotificationConfig)
+	input.NotificationConfig = config
+
+	return diags
+}
@@ -0,0 +1,143 @@
+package ssm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/region"
+)
+
+// Formats acceptés par l'API SendCommand pour la configuration de la sortie de la commande.
+var (
+	outputS3BucketNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	outputS3KeyPrefixRegexp  = regexp.MustCompile(`^.{1,500}$`)
+	logGroupNameRegexp       = regexp.MustCompile(`^[.\-_/#A-Za-z0-9]{1,512}$`)
+)
+
+// CloudWatchOutputConfigModel définit le modèle pour le bloc cloudwatch_output_config des
+// ressources qui envoient une commande SSM.
+type CloudWatchOutputConfigModel struct {
+	LogGroupName types.String `tfsdk:"log_group_name"`
+	Enabled      types.Bool   `tfsdk:"enabled"`
+}
+
+// outputS3BucketNameAttribute retourne l'attribut output_s3_bucket_name, le bucket S3 dans
+// lequel SSM enregistre la sortie complète de la commande.
+func outputS3BucketNameAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "The name of the S3 bucket where SSM stores the complete output of the command, which is not truncated unlike `invocations`. The URLs of the output files are exposed by `invocations`.",
+		Optional:            true,
+		Validators: []validator.String{
+			stringvalidatorRegexMatches(outputS3BucketNameRegexp, "output_s3_bucket_name must be a valid S3 bucket name"),
+		},
+	}
+}
+
+// outputS3KeyPrefixAttribute retourne l'attribut output_s3_key_prefix.
+func outputS3KeyPrefixAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "The prefix of the S3 keys of the output files. Requires `output_s3_bucket_name`.",
+		Optional:            true,
+		Validators: []validator.String{
+			stringvalidatorRegexMatches(outputS3KeyPrefixRegexp, "output_s3_key_prefix must be between 1 and 500 characters long"),
+		},
+	}
+}
+
+// outputS3RegionAttribute retourne l'attribut output_s3_region.
+func outputS3RegionAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "The region of the S3 bucket `output_s3_bucket_name`. Defaults to the region of the command. Requires `output_s3_bucket_name`.",
+		Optional:            true,
+		Validators: []validator.String{
+			region.Validator(),
+		},
+	}
+}
+
+// cloudWatchOutputConfigBlock retourne le bloc cloudwatch_output_config, qui envoie la sortie
+// complète de la commande vers CloudWatch Logs.
+func cloudWatchOutputConfigBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Sends the complete output of the command to CloudWatch Logs, which is not truncated unlike `invocations`. The log streams are exposed by `invocations`.",
+		Attributes: map[string]schema.Attribute{
+			"log_group_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the CloudWatch Logs log group. Defaults to a log group created by SSM and named after the document.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidatorRegexMatches(logGroupNameRegexp, "log_group_name must be between 1 and 512 characters long and contain only letters, digits and the characters . - _ / #"),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the output is sent to CloudWatch Logs. Defaults to `true`.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// validateOutputConfig vérifie au moment du plan que output_s3_key_prefix et output_s3_region
+// ne sont définis qu'avec output_s3_bucket_name. Les valeurs encore inconnues seront
+// vérifiées au plan suivant.
+func validateOutputConfig(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	var bucketName types.String
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("output_s3_bucket_name"), &bucketName)...)
+	if diagnostics.HasError() || !bucketName.IsNull() {
+		return diagnostics
+	}
+
+	for _, attribute := range []string{"output_s3_key_prefix", "output_s3_region"} {
+		var value types.String
+		diagnostics.Append(config.GetAttribute(ctx, path.Root(attribute), &value)...)
+		if !value.IsNull() {
+			diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Missing output_s3_bucket_name",
+				fmt.Sprintf("%s can only be set together with output_s3_bucket_name.", attribute),
+			)
+		}
+	}
+
+	return diagnostics
+}
+
+// applyOutputConfig reporte la configuration de la sortie de la commande (S3 et CloudWatch
+// Logs) sur l'entrée de l'API SendCommand.
+func applyOutputConfig(input *ssm.SendCommandInput, bucketName, keyPrefix, bucketRegion types.String, cloudWatch *CloudWatchOutputConfigModel) {
+	input.OutputS3BucketName = bucketName.ValueStringPointer()
+	input.OutputS3KeyPrefix = keyPrefix.ValueStringPointer()
+	input.OutputS3Region = bucketRegion.ValueStringPointer()
+
+	if cloudWatch != nil {
+		input.CloudWatchOutputConfig = &ssmtypes.CloudWatchOutputConfig{
+			CloudWatchLogGroupName:  cloudWatch.LogGroupName.ValueStringPointer(),
+			CloudWatchOutputEnabled: cloudWatch.Enabled.IsNull() || cloudWatch.Enabled.ValueBool(),
+		}
+	}
+}
+
+// cloudWatchLogStream retourne le nom du log stream CloudWatch Logs dans lequel SSM écrit la
+// sortie (stdout ou stderr) d'une étape de la commande sur une instance, ou une chaîne vide si
+// la sortie de la commande n'est pas envoyée vers CloudWatch Logs. Dans le nom du log stream,
+// les ":" du nom de l'étape sont remplacés par des "-" (aws:runShellScript devient
+// aws-runShellScript).
+func cloudWatchLogStream(commandId string, invocation ssmtypes.CommandInvocation, pluginName, output string) string {
+	if invocation.CloudWatchOutputConfig == nil || !invocation.CloudWatchOutputConfig.CloudWatchOutputEnabled {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s/%s/%s", commandId, aws.ToString(invocation.InstanceId), strings.ReplaceAll(pluginName, ":", "-"), output)
+}
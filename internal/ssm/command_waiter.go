@@ -0,0 +1,239 @@
+package ssm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/jd-ucpa/terraform-provider-test/internal/timeouts"
+	"github.com/jd-ucpa/terraform-provider-test/internal/waiter"
+)
+
+// commandTimeouts définit les délais par défaut des ressources qui envoient une commande SSM
+// (test_ssm_send_command et test_ssm_send_files). Seule la création envoie une commande :
+// un changement de triggers remplace la ressource.
+var commandTimeouts = timeouts.Defaults{
+	Create: 20 * time.Minute,
+}
+
+// Statuts d'une commande SSM, tels que calculés par SSM pour l'ensemble des instances ciblées.
+const (
+	commandStatusPending    = string(ssmtypes.CommandStatusPending)
+	commandStatusInProgress = string(ssmtypes.CommandStatusInProgress)
+	commandStatusCancelling = string(ssmtypes.CommandStatusCancelling)
+	commandStatusSuccess    = string(ssmtypes.CommandStatusSuccess)
+	commandStatusFailed     = string(ssmtypes.CommandStatusFailed)
+	commandStatusTimedOut   = string(ssmtypes.CommandStatusTimedOut)
+	commandStatusCancelled  = string(ssmtypes.CommandStatusCancelled)
+)
+
+// commandResult regroupe le statut d'une commande SSM et le résultat de ses invocations.
+type commandResult struct {
+	// Status est le statut global de la commande calculé par SSM : il tient compte de
+	// max_errors, une commande pouvant réussir malgré quelques invocations en échec.
+	Status string
+	// TargetCount est le nombre d'instances ciblées par la commande.
+	TargetCount int64
+	// Invocations contient le résultat de la commande sur chaque instance.
+	Invocations []ssmtypes.CommandInvocation
+}
+
+// countInvocations retourne le nombre d'invocations ayant le statut donné.
+func (r commandResult) countInvocations(status ssmtypes.CommandInvocationStatus) int64 {
+	var count int64
+	for _, invocation := range r.Invocations {
+		if invocation.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// waitForCommand attend la fin d'une commande SSM pendant au plus timeout, puis retourne
+// son statut final (Success, Failed, TimedOut ou Cancelled) ainsi que le résultat de ses
+// invocations. Une commande en échec n'est pas une erreur : c'est à l'appelant de décider
+// comment traiter le statut retourné.
+//
+// Seul le statut global de la commande est lu pendant l'attente, ce qui évite de parcourir
+// les invocations de toutes les instances à chaque lecture ; elles ne sont lues qu'une fois
+// la commande terminée.
+func waitForCommand(ctx context.Context, client *ssm.Client, commandId string, timeout time.Duration) (commandResult, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	command, err := waiter.Wait(ctx, waiter.Config[*ssmtypes.Command]{
+		Operation: fmt.Sprintf("SSM command '%s'", commandId),
+		Pending:   []string{commandStatusPending, commandStatusInProgress, commandStatusCancelling},
+		Target:    []string{commandStatusSuccess, commandStatusFailed, commandStatusTimedOut, commandStatusCancelled},
+		Refresh:   commandStatusRefreshFunc(client, commandId),
+		Timeout:   timeout,
+	})
+
+	var timeoutErr *waiter.TimeoutError
+	switch {
+	case err == nil:
+		result, err := readCommandResult(ctx, client, command)
+		if err != nil {
+			diagnostics.AddError(
+				"Unable to retrieve command invocations",
+				fmt.Sprintf("Error while reading the invocations of command '%s': %s. Please verify your AWS credentials and permissions.", commandId, err),
+			)
+		}
+		return result, diagnostics
+	case errors.As(err, &timeoutErr):
+		diagnostics.AddError(
+			"Timeout while waiting for SSM command to complete",
+			fmt.Sprintf("Timeout occurred while waiting on command '%s' (polled %d times over %s, last status '%s'). The command may still be running on the target instances.", commandId, timeoutErr.Attempts, timeoutErr.Timeout, timeoutErr.LastState),
+		)
+	case errors.Is(err, context.Canceled):
+		diagnostics.AddError(
+			"Operation cancelled",
+			fmt.Sprintf("Waiting on command '%s' was interrupted before completion: %s.", commandId, err),
+		)
+	default:
+		diagnostics.AddError(
+			"Unable to retrieve SSM command",
+			fmt.Sprintf("Error while waiting on command '%s': %s. Please verify your AWS credentials, permissions, and that the command exists.", commandId, err),
+		)
+	}
+
+	return commandResult{Status: commandStatusInProgress}, diagnostics
+}
+
+// commandStatusRefreshFunc retourne la RefreshFunc qui lit le statut global d'une commande SSM.
+func commandStatusRefreshFunc(client *ssm.Client, commandId string) waiter.RefreshFunc[*ssmtypes.Command] {
+	return func(ctx context.Context) (*ssmtypes.Command, string, error) {
+		command, err := getCommand(ctx, client, commandId)
+		if err != nil {
+			return nil, "", err
+		}
+
+		// L'API est à cohérence éventuelle : une commande tout juste envoyée peut ne pas
+		// encore être visible
+		if command == nil {
+			return nil, commandStatusPending, nil
+		}
+
+		return command, string(command.Status), nil
+	}
+}
+
+// getCommand lit une commande SSM. Elle retourne nil, sans erreur, si la commande ne figure
+// pas dans l'historique SSM (commande inconnue ou plus ancienne que 30 jours).
+func getCommand(ctx context.Context, client *ssm.Client, commandId string) (*ssmtypes.Command, error) {
+	output, err := client.ListCommands(ctx, &ssm.ListCommandsInput{
+		CommandId: aws.String(commandId),
+	})
+	var invalidCommandId *ssmtypes.InvalidCommandId
+	if errors.As(err, &invalidCommandId) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("calling AWS SSM ListCommands API: %w", err)
+	}
+	if len(output.Commands) == 0 {
+		return nil, nil
+	}
+
+	return &output.Commands[0], nil
+}
+
+// readCommandResult lit les invocations d'une commande SSM et retourne son résultat.
+func readCommandResult(ctx context.Context, client *ssm.Client, command *ssmtypes.Command) (commandResult, error) {
+	result := commandResult{
+		Status:      string(command.Status),
+		TargetCount: int64(command.TargetCount),
+	}
+
+	invocations, err := listCommandInvocations(ctx, client, aws.ToString(command.CommandId))
+	if err != nil {
+		return result, err
+	}
+	result.Invocations = invocations
+
+	return result, nil
+}
+
+// listCommandInvocations lit toutes les invocations d'une commande SSM avec le détail de
+// leurs plugins. L'API retourne au plus 50 invocations par page.
+func listCommandInvocations(ctx context.Context, client *ssm.Client, commandId string) ([]ssmtypes.CommandInvocation, error) {
+	var nextToken *string
+	var invocations []ssmtypes.CommandInvocation
+
+	for {
+		output, err := client.ListCommandInvocations(ctx, &ssm.ListCommandInvocationsInput{
+			CommandId: aws.String(commandId),
+			Details:   true,
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("calling AWS SSM ListCommandInvocations API: %w", err)
+		}
+
+		invocations = append(invocations, output.CommandInvocations...)
+
+		// Vérifier s'il y a plus de pages
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+
+	return invocations, nil
+}
+
+// commandDone indique si le statut d'une commande est un statut final.
+func commandDone(status string) bool {
+	switch status {
+	case commandStatusSuccess, commandStatusFailed, commandStatusTimedOut, commandStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// maxFailureDetailInvocations limite le nombre d'invocations décrites dans le diagnostic
+// d'une commande en échec, qui peut cibler des centaines d'instances.
+const maxFailureDetailInvocations = 10
+
+// commandFailureDetail décrit une commande SSM en échec pour le diagnostic de failure_mode :
+// pour chaque invocation en échec, le statut de l'instance puis le statut et la sortie
+// de chaque plugin en échec.
+func commandFailureDetail(commandId, status string, invocations []ssmtypes.CommandInvocation) string {
+	var detail strings.Builder
+	fmt.Fprintf(&detail, "Command '%s' completed with status '%s'.", commandId, status)
+
+	described, failed := 0, 0
+	for _, invocation := range invocations {
+		if invocation.Status == ssmtypes.CommandInvocationStatusSuccess {
+			continue
+		}
+
+		failed++
+		if described == maxFailureDetailInvocations {
+			continue
+		}
+		described++
+
+		fmt.Fprintf(&detail, "\n\nInstance %s: %s (%s)", aws.ToString(invocation.InstanceId), invocation.Status, aws.ToString(invocation.StatusDetails))
+		for _, plugin := range invocation.CommandPlugins {
+			if plugin.Status == ssmtypes.CommandPluginStatusSuccess {
+				continue
+			}
+			fmt.Fprintf(&detail, "\n  Plugin %s: %s (%s), response code %d", aws.ToString(plugin.Name), plugin.Status, aws.ToString(plugin.StatusDetails), plugin.ResponseCode)
+			if output := strings.TrimSpace(aws.ToString(plugin.Output)); output != "" {
+				fmt.Fprintf(&detail, "\n  Output:\n%s", output)
+			}
+		}
+	}
+
+	if failed > described {
+		fmt.Fprintf(&detail, "\n\n... and %d more failed instances.", failed-described)
+	}
+
+	return detail.String()
+}
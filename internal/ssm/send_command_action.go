@@ -0,0 +1,214 @@
+package ssm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+	"github.com/jd-ucpa/terraform-provider-test/internal/failuremode"
+	"github.com/jd-ucpa/terraform-provider-test/internal/region"
+	"github.com/jd-ucpa/terraform-provider-test/internal/timeouts"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &SendCommandAction{}
+var _ action.ActionWithConfigure = &SendCommandAction{}
+
+// NewSendCommandAction crée et retourne une nouvelle instance de l'action SendCommandAction.
+// Cette fonction est utilisée par le provider pour enregistrer l'action dans Terraform.
+func NewSendCommandAction() action.Action {
+	return &SendCommandAction{}
+}
+
+// SendCommandAction envoie une commande SSM et attend sa fin lorsqu'elle est invoquée, sans
+// rien enregistrer dans l'état. Contrairement à la ressource test_ssm_send_command, qui sert
+// de déclencheur via triggers, l'action est invoquée explicitement par un action_trigger ou
+// par `terraform apply -invoke`.
+type SendCommandAction struct {
+	meta *conns.ProviderMeta
+}
+
+// SendCommandActionModel définit le modèle de données pour l'action SendCommand.
+type SendCommandActionModel struct {
+	Region          types.String          `tfsdk:"region"`
+	DocumentName    types.String          `tfsdk:"document_name"`
+	InstanceIds     types.List            `tfsdk:"instance_ids"`
+	Targets         []TargetResourceModel `tfsdk:"targets"`
+	Parameters      types.Map             `tfsdk:"parameters"`
+	Comment         types.String          `tfsdk:"comment"`
+	DocumentVersion types.String          `tfsdk:"document_version"`
+	DocumentHash    types.String          `tfsdk:"document_hash"`
+	MaxConcurrency  types.String          `tfsdk:"max_concurrency"`
+	MaxErrors       types.String          `tfsdk:"max_errors"`
+	TimeoutSeconds  types.Int64           `tfsdk:"timeout_seconds"`
+	Timeout         types.String          `tfsdk:"timeout"`
+}
+
+// Metadata définit le nom du type d'action utilisé dans les configurations Terraform.
+func (a *SendCommandAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = "test_ssm_send_command"
+}
+
+// Configure conserve la configuration du provider pour créer le client SSM de la région demandée.
+func (a *SendCommandAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	meta, diags := conns.FromProviderData(req.ProviderData, "SSM send command action")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
+		return
+	}
+
+	// Conserver la configuration du provider pour créer les clients de la région de l'action
+	a.meta = meta
+}
+
+// Schema définit la structure et la documentation de l'action. Les attributs reprennent ceux
+// de la ressource test_ssm_send_command qui décrivent la commande ; les attributs calculés,
+// triggers et failure_mode n'ont pas de sens pour une action, qui échoue toujours si la
+// commande échoue.
+func (a *SendCommandAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `test_ssm_send_command` action sends a command to EC2 instances using AWS Systems Manager (SSM) and waits for it to complete. The action fails if the command does not succeed. Nothing is stored in the state. Requires Terraform 1.14 or later.",
+		Attributes: map[string]schema.Attribute{
+			"region": region.ActionAttribute(),
+			"document_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the SSM document to use.",
+				Required:            true,
+			},
+			"instance_ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The list of instance IDs where the command should be executed. Either instance_ids or targets must be specified.",
+				Optional:            true,
+			},
+			"parameters": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The parameters to pass to the SSM document.",
+				Optional:            true,
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "A comment about the command.",
+				Optional:            true,
+			},
+			"document_version": schema.StringAttribute{
+				MarkdownDescription: "The version of the SSM document to run: `$DEFAULT`, `$LATEST` or a version number. Defaults to the default version of the document.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidatorRegexMatches(documentVersionRegexp, "document_version must be $DEFAULT, $LATEST or a version number"),
+				},
+			},
+			"document_hash": schema.StringAttribute{
+				MarkdownDescription: "The SHA-256 hash of the SSM document. SSM refuses to run the command if the document does not match.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidatorRegexMatches(documentHashRegexp, "document_hash must be a SHA-256 hash (64 hexadecimal characters)"),
+				},
+			},
+			"max_concurrency": schema.StringAttribute{
+				MarkdownDescription: "The maximum number of instances that run the command at the same time, either a number (e.g. `10`) or a percentage of the targets (e.g. `10%`). Defaults to `50`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidatorRegexMatches(maxConcurrencyRegexp, "max_concurrency must be a positive number (e.g. 10) or a percentage between 1% and 100% (e.g. 10%)"),
+				},
+			},
+			"max_errors": schema.StringAttribute{
+				MarkdownDescription: "The number of errors, either a number (e.g. `1`) or a percentage of the targets (e.g. `10%`), after which SSM stops sending the command to the remaining instances. The command then fails. Defaults to `0`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidatorRegexMatches(maxErrorsRegexp, "max_errors must be a number without leading zeros (e.g. 1) or a percentage between 0% and 100% (e.g. 10%)"),
+				},
+			},
+			"timeout_seconds": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The time in seconds for the command to be delivered to an instance, between %d and %d. Defaults to 3600.", minCommandTimeoutSeconds, maxCommandTimeoutSeconds),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64BetweenValidator{attribute: "timeout_seconds", min: minCommandTimeoutSeconds, max: maxCommandTimeoutSeconds},
+				},
+			},
+			"timeout": timeouts.ActionAttribute(commandTimeouts.Create),
+		},
+		Blocks: map[string]schema.Block{
+			"targets": schema.ListNestedBlock{
+				MarkdownDescription: "The list of targets to send the command to. Either instance_ids or targets must be specified.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							MarkdownDescription: "The key of the target (e.g., 'InstanceIds', 'tag:Name', 'tag:Environment').",
+							Required:            true,
+						},
+						"values": schema.ListAttribute{
+							MarkdownDescription: "The values of the target.",
+							Required:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Invoke envoie la commande SSM et attend sa fin. L'envoi et l'attente sont ceux de la
+// ressource test_ssm_send_command, avec failure_mode = "error".
+func (a *SendCommandAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config SendCommandActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Construire le modèle de la ressource à partir de la configuration de l'action
+	data := SendCommandResourceModel{
+		Region:          config.Region,
+		DocumentName:    config.DocumentName,
+		InstanceIds:     config.InstanceIds,
+		Targets:         config.Targets,
+		Parameters:      config.Parameters,
+		Comment:         config.Comment,
+		DocumentVersion: config.DocumentVersion,
+		DocumentHash:    config.DocumentHash,
+		MaxConcurrency:  config.MaxConcurrency,
+		MaxErrors:       config.MaxErrors,
+		TimeoutSeconds:  config.TimeoutSeconds,
+		FailureMode:     types.StringValue(failuremode.Error),
+	}
+	r := &SendCommandResource{meta: a.meta}
+
+	// Valider et construire les targets
+	targets, diag := r.validateAndBuildTargets(ctx, data)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	// Convertir les paramètres
+	parameters, diag := r.convertParameters(ctx, data)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	// Récupérer le délai d'attente de la commande
+	timeout, diag := timeouts.Action(config.Timeout, commandTimeouts.Create)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Sending SSM command using document '%s' and waiting for it to complete", data.DocumentName.ValueString()),
+	})
+
+	// Exécuter la commande SSM
+	data, diag = r.executeSSMCommand(ctx, data, targets, parameters, timeout)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("SSM command '%s' completed with status '%s' (%d of %d instances succeeded)", data.CommandId.ValueString(), data.Status.ValueString(), data.SuccessCount.ValueInt64(), data.TargetCount.ValueInt64()),
+	})
+}
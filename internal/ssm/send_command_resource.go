@@ -2,7 +2,6 @@ package ssm
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
@@ -12,11 +11,22 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+	"github.com/jd-ucpa/terraform-provider-test/internal/failuremode"
+	"github.com/jd-ucpa/terraform-provider-test/internal/partition"
+	"github.com/jd-ucpa/terraform-provider-test/internal/region"
+	"github.com/jd-ucpa/terraform-provider-test/internal/timeouts"
+	"github.com/jd-ucpa/terraform-provider-test/internal/triggers"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &SendCommandResource{}
+var _ resource.ResourceWithValidateConfig = &SendCommandResource{}
 
 // NewSendCommandResource crée et retourne une nouvelle instance de la ressource
 // SendCommandResource. Cette fonction est utilisée par le provider pour enregistrer
@@ -29,7 +39,7 @@ func NewSendCommandResource() resource.Resource {
 // Cette ressource permet d'exécuter des commandes sur des instances AWS en utilisant
 // AWS Systems Manager (SSM) et surveille leur statut d'exécution.
 type SendCommandResource struct {
-	ssm *ssm.Client
+	meta *conns.ProviderMeta
 }
 
 // TargetResourceModel définit le modèle pour le bloc targets de la ressource.
@@ -43,15 +53,35 @@ type TargetResourceModel struct {
 // SendCommandResourceModel définit le modèle de données pour la ressource SendCommand.
 // Il contient tous les attributs de configuration et les données retournées par l'API SSM.
 type SendCommandResourceModel struct {
-	Id           types.String           `tfsdk:"id"`
-	DocumentName types.String           `tfsdk:"document_name"`
-	InstanceIds  types.List             `tfsdk:"instance_ids"`
-	Targets      []TargetResourceModel  `tfsdk:"targets"`
-	Parameters   types.Map              `tfsdk:"parameters"`
-	Comment      types.String           `tfsdk:"comment"`
-	CommandId    types.String           `tfsdk:"command_id"`
-	Status       types.String           `tfsdk:"status"`
-	Triggers     types.Map              `tfsdk:"triggers"`
+	Id                     types.String                 `tfsdk:"id"`
+	Region                 types.String                 `tfsdk:"region"`
+	DocumentName           types.String                 `tfsdk:"document_name"`
+	InstanceIds            types.List                   `tfsdk:"instance_ids"`
+	Targets                []TargetResourceModel        `tfsdk:"targets"`
+	Parameters             types.Map                    `tfsdk:"parameters"`
+	Comment                types.String                 `tfsdk:"comment"`
+	DocumentVersion        types.String                 `tfsdk:"document_version"`
+	DocumentHash           types.String                 `tfsdk:"document_hash"`
+	MaxConcurrency         types.String                 `tfsdk:"max_concurrency"`
+	MaxErrors              types.String                 `tfsdk:"max_errors"`
+	TimeoutSeconds         types.Int64                  `tfsdk:"timeout_seconds"`
+	ServiceRoleArn         types.String                 `tfsdk:"service_role_arn"`
+	NotificationConfig     *NotificationConfigModel     `tfsdk:"notification_config"`
+	OutputS3BucketName     types.String                 `tfsdk:"output_s3_bucket_name"`
+	OutputS3KeyPrefix      types.String                 `tfsdk:"output_s3_key_prefix"`
+	OutputS3Region         types.String                 `tfsdk:"output_s3_region"`
+	CloudWatchOutputConfig *CloudWatchOutputConfigModel `tfsdk:"cloudwatch_output_config"`
+	CommandId              types.String                 `tfsdk:"command_id"`
+	Status                 types.String                 `tfsdk:"status"`
+	Invocations            types.List                   `tfsdk:"invocations"`
+	TargetCount            types.Int64                  `tfsdk:"target_count"`
+	SuccessCount           types.Int64                  `tfsdk:"success_count"`
+	FailedCount            types.Int64                  `tfsdk:"failed_count"`
+	TimedOutCount          types.Int64                  `tfsdk:"timed_out_count"`
+	Triggers               types.Map                    `tfsdk:"triggers"`
+	TriggersReplace        types.Dynamic                `tfsdk:"triggers_replace"`
+	FailureMode            types.String                 `tfsdk:"failure_mode"`
+	Timeouts               types.Object                 `tfsdk:"timeouts"`
 }
 
 // Metadata définit le nom du type de ressource utilisé dans les configurations Terraform.
@@ -64,23 +94,14 @@ func (r *SendCommandResource) Metadata(ctx context.Context, req resource.Metadat
 // Cette méthode est appelée par Terraform pour configurer la ressource avec
 // les paramètres d'authentification AWS (région, credentials, etc.).
 func (r *SendCommandResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	// Éviter le panic si le provider n'a pas été configuré
-	if req.ProviderData == nil {
+	meta, diags := conns.FromProviderData(req.ProviderData, "SSM send command resource")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
 		return
 	}
 
-	// Vérifier que la configuration est du bon type
-	config, ok := req.ProviderData.(aws.Config)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Provider configuration error",
-			fmt.Sprintf("Expected aws.Config for SSM send command resource, got: %T. This indicates a provider configuration issue. Please verify your provider configuration and report this issue if it persists.", req.ProviderData),
-		)
-		return
-	}
-	
-	// Créer le client SSM à partir de la configuration AWS
-	r.ssm = ssm.NewFromConfig(config)
+	// Conserver la configuration du provider pour créer les clients de la région de la ressource
+	r.meta = meta
 }
 
 // Schema définit la structure et la documentation de la ressource.
@@ -91,9 +112,13 @@ func (r *SendCommandResource) Schema(ctx context.Context, req resource.SchemaReq
 		MarkdownDescription: "The `test_ssm_send_command` resource allows you to send commands to EC2 instances using AWS Systems Manager (SSM). This resource supports targeting instances by instance IDs or by using target blocks for more flexible targeting options like EC2 tags.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Computed: true,
+				Computed:            true,
 				MarkdownDescription: "Identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
+			"region": region.ResourceAttribute(),
 			"document_name": schema.StringAttribute{
 				MarkdownDescription: "The name of the SSM document to use.",
 				Required:            true,
@@ -112,21 +137,102 @@ func (r *SendCommandResource) Schema(ctx context.Context, req resource.SchemaReq
 				MarkdownDescription: "A comment about the command.",
 				Optional:            true,
 			},
+			"document_version": schema.StringAttribute{
+				MarkdownDescription: "The version of the SSM document to run: `$DEFAULT`, `$LATEST` or a version number. Defaults to the default version of the document.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidatorRegexMatches(documentVersionRegexp, "document_version must be $DEFAULT, $LATEST or a version number"),
+				},
+			},
+			"document_hash": schema.StringAttribute{
+				MarkdownDescription: "The SHA-256 hash of the SSM document. SSM refuses to run the command if the document does not match, which protects against a document modified since it was reviewed.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidatorRegexMatches(documentHashRegexp, "document_hash must be a SHA-256 hash (64 hexadecimal characters)"),
+				},
+			},
+			"max_concurrency": schema.StringAttribute{
+				MarkdownDescription: "The maximum number of instances that run the command at the same time, either a number (e.g. `10`) or a percentage of the targets (e.g. `10%`). Defaults to `50`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidatorRegexMatches(maxConcurrencyRegexp, "max_concurrency must be a positive number (e.g. 10) or a percentage between 1% and 100% (e.g. 10%)"),
+				},
+			},
+			"max_errors": schema.StringAttribute{
+				MarkdownDescription: "The number of errors, either a number (e.g. `1`) or a percentage of the targets (e.g. `10%`), after which SSM stops sending the command to the remaining instances. The command then fails. Defaults to `0`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidatorRegexMatches(maxErrorsRegexp, "max_errors must be a number without leading zeros (e.g. 1) or a percentage between 0% and 100% (e.g. 10%)"),
+				},
+			},
+			"timeout_seconds": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The time in seconds for the command to be delivered to an instance, between %d and %d. An instance that has not started the command in time gets the `TimedOut` status. The execution time of the command itself is set by the `executionTimeout` parameter of the document. Defaults to 3600.", minCommandTimeoutSeconds, maxCommandTimeoutSeconds),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64BetweenValidator{attribute: "timeout_seconds", min: minCommandTimeoutSeconds, max: maxCommandTimeoutSeconds},
+				},
+			},
+			"service_role_arn": schema.StringAttribute{
+				MarkdownDescription: "The ARN of the IAM role that SSM uses to publish notifications to the SNS topic of `notification_config`.",
+				Optional:            true,
+				Validators: []validator.String{
+					partition.RoleARNValidator(),
+				},
+			},
+			"output_s3_bucket_name": outputS3BucketNameAttribute(),
+			"output_s3_key_prefix":  outputS3KeyPrefixAttribute(),
+			"output_s3_region":      outputS3RegionAttribute(),
 			"command_id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the command that was sent.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"status": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "The status of the command.",
+				MarkdownDescription: "The status of the command across all targeted instances, as reported by SSM (Pending, InProgress, Success, Failed, TimedOut, Cancelling or Cancelled). With `max_errors`, a command can succeed even if some instances failed: see `failed_count` and `timed_out_count`. It is refreshed from SSM, so a command still running when the apply timed out gets its final status on the next plan or refresh. The resource is removed from the state once the command has aged out of the SSM command history (30 days).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"invocations":      invocationsAttribute(),
+			"target_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of instances targeted by the command.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
-			"triggers": schema.MapAttribute{
-				ElementType:         types.StringType,
-				MarkdownDescription: "A map of arbitrary strings that, when changed, will force the resource to be recreated.",
-				Optional:            true,
+			"success_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of instances on which the command succeeded.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"failed_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of instances on which the command failed, including instances the command could not be delivered to.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
+			"timed_out_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of instances on which the command timed out, either before being delivered or while running.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"triggers":         triggers.ResourceAttribute(),
+			"triggers_replace": triggers.ReplaceResourceAttribute(),
+			"failure_mode":     failuremode.ResourceAttribute(),
 		},
 		Blocks: map[string]schema.Block{
+			"timeouts":                 timeouts.Block(commandTimeouts),
+			"notification_config":      notificationConfigBlock(),
+			"cloudwatch_output_config": cloudWatchOutputConfigBlock(),
 			"targets": schema.ListNestedBlock{
 				MarkdownDescription: "The list of targets to send the command to. Either instance_ids or targets must be specified.",
 				NestedObject: schema.NestedBlockObject{
@@ -147,6 +253,13 @@ func (r *SendCommandResource) Schema(ctx context.Context, req resource.SchemaReq
 	}
 }
 
+// ValidateConfig vérifie au moment du plan les contraintes entre attributs, que les
+// validateurs d'un attribut isolé ne peuvent pas exprimer.
+func (r *SendCommandResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateNotificationConfig(ctx, req.Config)...)
+	resp.Diagnostics.Append(validateOutputConfig(ctx, req.Config)...)
+}
+
 // Create envoie une nouvelle commande SSM vers les instances ciblées.
 // Cette méthode est appelée par Terraform lors de la création d'une ressource.
 // Elle valide la configuration, envoie la commande SSM et surveille son statut.
@@ -171,22 +284,33 @@ func (r *SendCommandResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	// Exécuter la commande SSM
-	data, diag = r.executeSSMCommand(ctx, data, targets, parameters)
+	// Récupérer le délai d'attente de la commande
+	createTimeout, diag := timeouts.Create(data.Timeouts, commandTimeouts)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
+	// Exécuter la commande SSM. Une fois la commande envoyée, l'état est enregistré même en cas
+	// d'erreur (délai dépassé, failure_mode = "error") : Terraform marque alors la ressource
+	// comme tainted et la commande sera renvoyée au prochain apply.
+	data, diag = r.executeSSMCommand(ctx, data, targets, parameters, createTimeout)
+	resp.Diagnostics.Append(diag...)
+	if data.CommandId.IsUnknown() || data.CommandId.IsNull() {
+		return
+	}
+
 	// Normaliser les valeurs optionnelles
 	r.normalizeOptionalValues(&data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// Read récupère l'état actuel de la ressource depuis l'état Terraform.
+// Read récupère le statut de la commande depuis SSM.
 // Cette méthode est appelée par Terraform pour synchroniser l'état local avec l'état distant.
-// Pour cette ressource, l'état est conservé tel quel car les commandes SSM sont statiques.
+// Le statut est recalculé à partir des invocations de la commande, ce qui met à jour une
+// commande encore en cours lors de l'apply (délai dépassé). SSM ne conserve l'historique des
+// commandes que 30 jours : une commande qui n'y figure plus est retirée de l'état.
 func (r *SendCommandResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data SendCommandResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -194,87 +318,63 @@ func (r *SendCommandResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	// Dans une implémentation réelle, vous vérifieriez le statut de la commande
-	// Pour l'instant, on garde l'état actuel
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
+	// Utiliser le client de la région de la ressource
+	conn := r.meta.SSMClient(data.Region.ValueString())
 
-// Update gère les modifications de la ressource.
-// Cette méthode est appelée par Terraform lors de la modification d'une ressource existante.
-// Elle vérifie si les triggers ont changé et exécute une nouvelle commande si nécessaire.
-func (r *SendCommandResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data SendCommandResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
+	// Un état sans command_id ne peut pas être rafraîchi, on le conserve tel quel
+	commandId := data.CommandId.ValueString()
+	if commandId == "" {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 		return
 	}
 
-	// Récupérer l'état actuel pour comparer les triggers
-	var currentData SendCommandResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &currentData)...)
-	if resp.Diagnostics.HasError() {
+	// Vérifier que la commande figure encore dans l'historique SSM
+	command, err := getCommand(ctx, conn, commandId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to retrieve SSM command",
+			fmt.Sprintf("Error while reading command '%s': %s. Please verify your AWS credentials and permissions.", commandId, err),
+		)
+		return
+	}
+	if command == nil {
+		// La commande a expiré de l'historique SSM, la marquer pour suppression
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	// Vérifier si les triggers ont changé
-	triggersChanged := !data.Triggers.Equal(currentData.Triggers)
-
-	if triggersChanged {
-		// Si les triggers ont changé, exécuter une nouvelle commande SSM
-		// Valider et construire les targets
-		targets, diag := r.validateAndBuildTargets(ctx, data)
-		if diag.HasError() {
-			resp.Diagnostics.Append(diag...)
-			return
-		}
-
-		// Convertir les paramètres
-		parameters, diag := r.convertParameters(ctx, data)
-		if diag.HasError() {
-			resp.Diagnostics.Append(diag...)
+	// Les résultats d'une commande terminée ne changent plus : ils ne sont relus que si le
+	// statut a changé depuis la dernière lecture ou s'ils n'ont pas encore été enregistrés
+	status := string(command.Status)
+	if data.Invocations.IsNull() || !commandDone(status) || status != data.Status.ValueString() {
+		result, err := readCommandResult(ctx, conn, command)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to retrieve command invocations",
+				fmt.Sprintf("Error while reading the invocations of command '%s': %s. Please verify your AWS credentials and permissions.", commandId, err),
+			)
 			return
 		}
 
-		// Exécuter la commande SSM
-		data, diag = r.executeSSMCommand(ctx, data, targets, parameters)
-		if diag.HasError() {
-			resp.Diagnostics.Append(diag...)
+		resp.Diagnostics.Append(r.mapCommandResult(ctx, conn, &data, result)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
-	} else {
-		// Si les triggers n'ont pas changé, préserver les valeurs calculées
-		if data.Id.IsUnknown() || data.Id.IsNull() {
-			data.Id = currentData.Id
-		}
-		if data.CommandId.IsUnknown() || data.CommandId.IsNull() {
-			data.CommandId = currentData.CommandId
-		}
-		if data.Status.IsUnknown() || data.Status.IsNull() {
-			data.Status = currentData.Status
-		}
-		// Préserver aussi les valeurs optionnelles de l'état actuel
-		if data.Comment.IsUnknown() || data.Comment.IsNull() {
-			data.Comment = currentData.Comment
-		}
-		if data.Parameters.IsUnknown() || data.Parameters.IsNull() {
-			data.Parameters = currentData.Parameters
-		}
 	}
 
-	// S'assurer que les valeurs calculées sont toujours définies
-	if data.Id.IsUnknown() || data.Id.IsNull() {
-		data.Id = types.StringValue("")
-	}
-	if data.CommandId.IsUnknown() || data.CommandId.IsNull() {
-		data.CommandId = types.StringValue("")
-	}
-	if data.Status.IsUnknown() || data.Status.IsNull() {
-		data.Status = types.StringValue("")
-	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
 
-	// Normaliser les valeurs optionnelles seulement si les triggers ont changé
-	if triggersChanged {
-		r.normalizeOptionalValues(&data)
+// Update gère les modifications de la ressource.
+// Cette méthode est appelée par Terraform lors de la modification d'une ressource existante.
+// Un changement de triggers ou de triggers_replace entraîne le remplacement de la ressource :
+// une mise à jour ne renvoie donc jamais la commande et enregistre simplement le plan, dont
+// les valeurs calculées sont conservées par les plan modifiers UseStateForUnknown.
+func (r *SendCommandResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SendCommandResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -284,154 +384,6 @@ func (r *SendCommandResource) Delete(ctx context.Context, req resource.DeleteReq
 	// Les commandes SSM ne peuvent pas être supprimées, on ne fait rien
 }
 
-// PollCommandInvocation vérifie le statut d'une commande SSM
-// Retourne des diagnostics avec :
-// - Error : Erreur fatale (commande échouée)
-// - Warning : Commande encore en cours (continuer polling)
-// - Vide : Commande terminée avec succès
-func PollCommandInvocation(ctx context.Context, client *ssm.Client, command *ssm.SendCommandOutput) diag.Diagnostics {
-	diagnostics := diag.Diagnostics{}
-	// fmt.Printf("DEBUG: Polling command %s\n", *command.Command.CommandId)
-
-	// Récupérer les détails de la commande
-	listCommandInvocationsOutput, err := client.ListCommandInvocations(ctx, &ssm.ListCommandInvocationsInput{
-		CommandId: command.Command.CommandId,
-		Details:   true,
-	})
-	if err != nil {
-		diagnostics.AddError(
-			"Unable to retrieve command invocations",
-			fmt.Sprintf("Error calling AWS SSM ListCommandInvocations API for command '%s': %s. Please verify your AWS credentials, permissions, and that the command exists.", *command.Command.CommandId, err),
-		)
-		return diagnostics
-	}
-
-	invocationCount := int32(len(listCommandInvocationsOutput.CommandInvocations))
-	// fmt.Printf("DEBUG: Found %d invocations\n", invocationCount)
-	
-	if invocationCount == 0 {
-		// Continue polling as the command invocation is not yet available and the api is eventually consistent
-		diagnostics.AddWarning("Command Invocations Not Found", fmt.Sprintf("No command invocations found for command ID: %s", *command.Command.CommandId))
-		return diagnostics
-	}
-
-	// Vérifier le statut de chaque invocation
-	allCompleted := true
-	hasErrors := false
-	
-	for _, invocation := range listCommandInvocationsOutput.CommandInvocations {
-		// fmt.Printf("DEBUG: Invocation %s status: %s\n", *invocation.InstanceId, invocation.Status)
-		
-		// Vérifier si la commande est encore en cours
-		if invocation.Status == ssmtypes.CommandInvocationStatusPending ||
-			invocation.Status == ssmtypes.CommandInvocationStatusInProgress ||
-			invocation.Status == ssmtypes.CommandInvocationStatusDelayed ||
-			invocation.Status == ssmtypes.CommandInvocationStatusCancelling {
-			allCompleted = false
-			diagnostics.AddWarning("Command Invocation In Progress", fmt.Sprintf("Command invocation is still in progress for command ID: %s, instance ID: %s", *command.Command.CommandId, *invocation.InstanceId))
-		}
-
-		// Vérifier les erreurs d'invocation
-		if invocation.Status == ssmtypes.CommandInvocationStatusFailed ||
-			invocation.Status == ssmtypes.CommandInvocationStatusTimedOut ||
-			invocation.Status == ssmtypes.CommandInvocationStatusCancelled {
-			hasErrors = true
-			diagnostics.AddError(
-				"Command invocation failed",
-				fmt.Sprintf("Command invocation failed for instance '%s': %s. Please check the instance status and SSM agent configuration.", *invocation.InstanceId, *invocation.StatusDetails),
-			)
-		}
-
-		// Vérifier les plugins pour détecter les erreurs
-		for _, plugin := range invocation.CommandPlugins {
-			// fmt.Printf("DEBUG: Plugin %s status: %s\n", *plugin.Name, plugin.Status)
-			if plugin.Status == ssmtypes.CommandPluginStatusInProgress ||
-				plugin.Status == ssmtypes.CommandPluginStatusPending {
-				// Ces états indiquent que la commande est encore en cours
-				allCompleted = false
-			} else if plugin.Status != ssmtypes.CommandPluginStatusSuccess {
-				// Seuls les états autres que Success, InProgress, Pending, Delayed sont des erreurs
-				hasErrors = true
-				diagnostics.AddError(
-					fmt.Sprintf("Plugin '%s' failed", *plugin.Name),
-					fmt.Sprintf("Plugin '%s' failed with status details: %s. Command: %s, Instance: %s, Output: %s. Please check the plugin configuration and target instance.", *plugin.Name, *plugin.StatusDetails, *command.Command.CommandId, *invocation.InstanceId, *plugin.Output),
-				)
-			}
-		}
-	}
-
-	// Si il y a des erreurs, retourner immédiatement
-	if hasErrors {
-		// fmt.Printf("DEBUG: Command has errors, returning error diagnostics\n")
-		return diagnostics
-	}
-
-	// Si toutes les invocations ne sont pas terminées, continuer le polling
-	if !allCompleted {
-		// fmt.Printf("DEBUG: Command still in progress, returning warning diagnostics\n")
-		return diagnostics
-	}
-
-	// Si on arrive ici, toutes les invocations sont terminées avec succès
-	// fmt.Printf("DEBUG: All invocations completed successfully, returning empty diagnostics\n")
-	return diag.Diagnostics{} // Retourner des diagnostics vides pour indiquer le succès
-}
-
-// getActualCommandStatus récupère le statut réel de la commande SSM depuis AWS.
-// Cette fonction analyse les invocations de commande et leurs plugins pour déterminer
-// le statut final de la commande (Success, Failed, TimedOut, Cancelled, Unknown).
-func getActualCommandStatus(ctx context.Context, client *ssm.Client, command *ssm.SendCommandOutput) string {
-	// fmt.Printf("DEBUG: Getting actual status for command %s\n", *command.Command.CommandId)
-	
-	// Récupérer les détails de la commande
-	listCommandInvocationsOutput, err := client.ListCommandInvocations(ctx, &ssm.ListCommandInvocationsInput{
-		CommandId: command.Command.CommandId,
-		Details:   true,
-	})
-	if err != nil {
-		// fmt.Printf("DEBUG: Error getting command invocations: %s\n", err)
-		return "Unknown"
-	}
-
-	// fmt.Printf("DEBUG: Found %d command invocations\n", len(listCommandInvocationsOutput.CommandInvocations))
-	
-	// Vérifier le statut de chaque invocation
-	for _, invocation := range listCommandInvocationsOutput.CommandInvocations {
-		// fmt.Printf("DEBUG: Invocation status: %s\n", invocation.Status)
-		
-		// Vérifier les plugins pour détecter les erreurs
-		for _, plugin := range invocation.CommandPlugins {
-			// fmt.Printf("DEBUG: Plugin %s status: %s\n", *plugin.Name, plugin.Status)
-			if plugin.Status != ssmtypes.CommandPluginStatusSuccess {
-				// fmt.Printf("DEBUG: Plugin failed, returning Failed\n")
-				return "Failed"
-			}
-		}
-
-		// Vérifier le statut de l'invocation
-		switch invocation.Status {
-		case ssmtypes.CommandInvocationStatusSuccess:
-			// fmt.Printf("DEBUG: Invocation success, returning Success\n")
-			return "Success"
-		case ssmtypes.CommandInvocationStatusFailed:
-			// fmt.Printf("DEBUG: Invocation failed, returning Failed\n")
-			return "Failed"
-		case ssmtypes.CommandInvocationStatusTimedOut:
-			// fmt.Printf("DEBUG: Invocation timed out, returning TimedOut\n")
-			return "TimedOut"
-		case ssmtypes.CommandInvocationStatusCancelled:
-			// fmt.Printf("DEBUG: Invocation cancelled, returning Cancelled\n")
-			return "Cancelled"
-		default:
-			// fmt.Printf("DEBUG: Unknown invocation status, returning Unknown\n")
-			return "Unknown"
-		}
-	}
-
-	// fmt.Printf("DEBUG: No invocations found, returning Unknown\n")
-	return "Unknown"
-}
-
 // validateAndBuildTargets valide les paramètres et construit les targets pour l'API SSM
 func (r *SendCommandResource) validateAndBuildTargets(ctx context.Context, data SendCommandResourceModel) ([]ssmtypes.Target, diag.Diagnostics) {
 	var diagnostics diag.Diagnostics
@@ -512,17 +464,27 @@ func (r *SendCommandResource) convertParameters(ctx context.Context, data SendCo
 	return parameters, diagnostics
 }
 
-// executeSSMCommand exécute une commande SSM et gère le polling
-func (r *SendCommandResource) executeSSMCommand(ctx context.Context, data SendCommandResourceModel, targets []ssmtypes.Target, parameters map[string][]string) (SendCommandResourceModel, diag.Diagnostics) {
+// executeSSMCommand exécute une commande SSM et attend sa fin pendant au plus timeout
+func (r *SendCommandResource) executeSSMCommand(ctx context.Context, data SendCommandResourceModel, targets []ssmtypes.Target, parameters map[string][]string, timeout time.Duration) (SendCommandResourceModel, diag.Diagnostics) {
 	var diagnostics diag.Diagnostics
 	
-	// Envoyer la commande SSM
-	command, err := r.ssm.SendCommand(ctx, &ssm.SendCommandInput{
+	input := &ssm.SendCommandInput{
 		DocumentName: aws.String(data.DocumentName.ValueString()),
 		Targets:      targets,
 		Parameters:   parameters,
 		Comment:      data.Comment.ValueStringPointer(),
-	})
+	}
+
+	// Ajouter les options d'exécution et la configuration de la sortie de la commande
+	diagnostics.Append(applyExecutionOptions(ctx, input, data)...)
+	if diagnostics.HasError() {
+		return data, diagnostics
+	}
+	applyOutputConfig(input, data.OutputS3BucketName, data.OutputS3KeyPrefix, data.OutputS3Region, data.CloudWatchOutputConfig)
+
+	// Envoyer la commande SSM avec le client de la région de la ressource
+	conn := r.meta.SSMClient(data.Region.ValueString())
+	command, err := conn.SendCommand(ctx, input)
 	if err != nil {
 		diagnostics.AddError(
 			"Unable to send SSM command",
@@ -533,75 +495,49 @@ func (r *SendCommandResource) executeSSMCommand(ctx context.Context, data SendCo
 
 	data.Id = types.StringValue(*command.Command.CommandId)
 	data.CommandId = types.StringValue(*command.Command.CommandId)
-	data.Status = types.StringValue("InProgress")
-
-	// Polling de la commande avec timeout
-	createTimeout := 5 * time.Minute
-	ctx, cancel := context.WithTimeout(ctx, createTimeout)
-	defer cancel()
+	data.Status = types.StringValue(commandStatusInProgress)
+	data.Invocations = invocationsNull()
+	data.TargetCount = types.Int64Value(int64(command.Command.TargetCount))
+	data.SuccessCount = types.Int64Null()
+	data.FailedCount = types.Int64Null()
+	data.TimedOutCount = types.Int64Null()
+
+	// Attendre la fin de la commande
+	result, waitDiags := waitForCommand(ctx, conn, data.CommandId.ValueString(), timeout)
+	diagnostics.Append(waitDiags...)
+	if diagnostics.HasError() {
+		return data, diagnostics
+	}
 
-	backoff := time.Second
-	maxAttempts := 10 // Limiter le nombre de tentatives
-	for attempt := 0; attempt < maxAttempts; attempt++ {
-		if ctx.Err() != nil {
-			diagnostics.AddError(
-				"Operation cancelled",
-				fmt.Sprintf("Context cancelled before attempt %d: %s. The operation was interrupted before completion.", attempt+1, ctx.Err()),
-			)
-			return data, diagnostics
-		}
+	// Enregistrer le statut de la commande et son résultat sur chaque instance
+	diagnostics.Append(r.mapCommandResult(ctx, conn, &data, result)...)
+	if diagnostics.HasError() {
+		return data, diagnostics
+	}
 
-		// Diagnostics with Severity warnings are treated as retriable errors
-		attemptDiag := PollCommandInvocation(ctx, r.ssm, command)
-		if attemptDiag.HasError() {
-			// La commande SSM a échoué, mais on ne fait pas échouer terraform apply
-			// On met juste le statut à "Failed" et on continue
-			// fmt.Printf("DEBUG: SSM command failed, setting status to Failed\n")
-			data.Status = types.StringValue("Failed")
-			return data, diagnostics
-		} else if attemptDiag.WarningsCount() > 0 {
-			// Retry with exponential backoff
-			select {
-			case <-time.After(backoff):
-				// Exponential backoff with a maximum of 30 seconds
-				backoff *= 2
-				if backoff > 30*time.Second {
-					backoff = 30 * time.Second
-				}
-				continue
-			case <-ctx.Done():
-				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-					diagnostics.AddError(
-						"Timeout while waiting for SSM command to complete",
-						fmt.Sprintf("Timeout occurred while waiting on command '%s' (polled %d times over %s). The command may still be running on the target instances.", *command.Command.CommandId, attempt+1, createTimeout),
-					)
-					return data, diagnostics
-				} else {
-					diagnostics.AddError(
-						"Operation cancelled",
-						fmt.Sprintf("Context cancelled before attempt %d: %s. The operation was interrupted before completion.", attempt+1, ctx.Err()),
-					)
-					return data, diagnostics
-				}
-			}
-		} else {
-			// Command completed - get the actual status from AWS
-			// fmt.Printf("DEBUG: Polling completed, getting actual status\n")
-			actualStatus := getActualCommandStatus(ctx, r.ssm, command)
-			// fmt.Printf("DEBUG: Actual status: %s\n", actualStatus)
-			data.Status = types.StringValue(actualStatus)
-			return data, diagnostics
-		}
+	// Signaler l'échec de la commande selon failure_mode
+	if result.Status != commandStatusSuccess {
+		diagnostics.Append(failuremode.Diagnostics(data.FailureMode, "SSM command failed", commandFailureDetail(data.CommandId.ValueString(), result.Status, result.Invocations))...)
 	}
-	
-	// Si on arrive ici, on a dépassé le nombre max de tentatives
-	// fmt.Printf("DEBUG: Max attempts reached, getting final status\n")
-	actualStatus := getActualCommandStatus(ctx, r.ssm, command)
-	// fmt.Printf("DEBUG: Final status: %s\n", actualStatus)
-	data.Status = types.StringValue(actualStatus)
+
 	return data, diagnostics
 }
 
+// mapCommandResult mappe le résultat de la commande vers le modèle Terraform : statut global,
+// nombre d'instances par statut et résultat de chaque invocation.
+func (r *SendCommandResource) mapCommandResult(ctx context.Context, conn *ssm.Client, data *SendCommandResourceModel, result commandResult) diag.Diagnostics {
+	data.Status = types.StringValue(result.Status)
+	data.TargetCount = types.Int64Value(result.TargetCount)
+	data.SuccessCount = types.Int64Value(result.countInvocations(ssmtypes.CommandInvocationStatusSuccess))
+	data.FailedCount = types.Int64Value(result.countInvocations(ssmtypes.CommandInvocationStatusFailed))
+	data.TimedOutCount = types.Int64Value(result.countInvocations(ssmtypes.CommandInvocationStatusTimedOut))
+
+	invocations, diags := invocationsValue(ctx, conn, data.CommandId.ValueString(), result.Invocations)
+	data.Invocations = invocations
+
+	return diags
+}
+
 // normalizeOptionalValues s'assure que les valeurs optionnelles sont définies
 func (r *SendCommandResource) normalizeOptionalValues(data *SendCommandResourceModel) {
 	// Ne pas forcer les valeurs null à devenir des chaînes vides
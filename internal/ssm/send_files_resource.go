@@ -3,7 +3,6 @@ package ssm
 import (
 	"context"
 	"encoding/base64"
-	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -16,16 +15,21 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+	"github.com/jd-ucpa/terraform-provider-test/internal/failuremode"
+	"github.com/jd-ucpa/terraform-provider-test/internal/region"
+	"github.com/jd-ucpa/terraform-provider-test/internal/timeouts"
+	"github.com/jd-ucpa/terraform-provider-test/internal/triggers"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &SendFilesResource{}
 var _ resource.ResourceWithImportState = &SendFilesResource{}
+var _ resource.ResourceWithValidateConfig = &SendFilesResource{}
 
 // stringvalidator.RegexMatches equivalent
 type regexMatchesValidator struct {
@@ -107,22 +111,31 @@ func NewSendFilesResource() resource.Resource {
 
 // SendFilesResource defines the resource implementation.
 type SendFilesResource struct {
-	ssm *ssm.Client
+	meta *conns.ProviderMeta
 }
 
 // SendFilesResourceModel describes the resource data model.
 type SendFilesResourceModel struct {
-	Id                 types.String `tfsdk:"id"`
-	CommandId          types.String `tfsdk:"command_id"`
-	Status             types.String `tfsdk:"status"`
-	Platform           types.String `tfsdk:"platform"`
-	InstanceIds        types.List   `tfsdk:"instance_ids"`
-	Targets            []Target     `tfsdk:"targets"`
-	WorkingDirectory   types.String `tfsdk:"working_directory"`
-	ScriptBeforeFiles  types.String `tfsdk:"script_before_files"`
-	ScriptAfterFiles   types.String `tfsdk:"script_after_files"`
-	Files              []File       `tfsdk:"file"`
-	Triggers           types.Map    `tfsdk:"triggers"`
+	Id                     types.String                 `tfsdk:"id"`
+	Region                 types.String                 `tfsdk:"region"`
+	CommandId              types.String                 `tfsdk:"command_id"`
+	Status                 types.String                 `tfsdk:"status"`
+	Invocations            types.List                   `tfsdk:"invocations"`
+	Platform               types.String                 `tfsdk:"platform"`
+	InstanceIds            types.List                   `tfsdk:"instance_ids"`
+	Targets                []Target                     `tfsdk:"targets"`
+	WorkingDirectory       types.String                 `tfsdk:"working_directory"`
+	ScriptBeforeFiles      types.String                 `tfsdk:"script_before_files"`
+	ScriptAfterFiles       types.String                 `tfsdk:"script_after_files"`
+	Files                  []File                       `tfsdk:"file"`
+	OutputS3BucketName     types.String                 `tfsdk:"output_s3_bucket_name"`
+	OutputS3KeyPrefix      types.String                 `tfsdk:"output_s3_key_prefix"`
+	OutputS3Region         types.String                 `tfsdk:"output_s3_region"`
+	CloudWatchOutputConfig *CloudWatchOutputConfigModel `tfsdk:"cloudwatch_output_config"`
+	Triggers               types.Map                    `tfsdk:"triggers"`
+	TriggersReplace        types.Dynamic                `tfsdk:"triggers_replace"`
+	FailureMode            types.String                 `tfsdk:"failure_mode"`
+	Timeouts               types.Object                 `tfsdk:"timeouts"`
 }
 
 // Target represents a target for SSM command
@@ -239,6 +252,7 @@ func (r *SendFilesResource) Schema(ctx context.Context, req resource.SchemaReque
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"region": region.ResourceAttribute(),
 			"command_id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the SSM command",
@@ -253,6 +267,7 @@ func (r *SendFilesResource) Schema(ctx context.Context, req resource.SchemaReque
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"invocations": invocationsAttribute(),
 			"platform": schema.StringAttribute{
 				MarkdownDescription: "The platform (linux or windows)",
 				Required:            true,
@@ -274,16 +289,16 @@ func (r *SendFilesResource) Schema(ctx context.Context, req resource.SchemaReque
 				MarkdownDescription: "Script to execute after creating files",
 				Optional:            true,
 			},
-			"triggers": schema.MapAttribute{
-				ElementType:         types.StringType,
-				MarkdownDescription: "Triggers to force recreation",
-				Optional:            true,
-				PlanModifiers: []planmodifier.Map{
-					mapplanmodifier.RequiresReplace(),
-				},
-			},
+			"output_s3_bucket_name": outputS3BucketNameAttribute(),
+			"output_s3_key_prefix":  outputS3KeyPrefixAttribute(),
+			"output_s3_region":      outputS3RegionAttribute(),
+			"triggers":              triggers.ResourceAttribute(),
+			"triggers_replace":      triggers.ReplaceResourceAttribute(),
+			"failure_mode":          failuremode.ResourceAttribute(),
 		},
 		Blocks: map[string]schema.Block{
+			"timeouts":                 timeouts.Block(commandTimeouts),
+			"cloudwatch_output_config": cloudWatchOutputConfigBlock(),
 			"targets": schema.ListNestedBlock{
 				MarkdownDescription: "Targets for the SSM command",
 				NestedObject: schema.NestedBlockObject{
@@ -343,24 +358,20 @@ func (r *SendFilesResource) Schema(ctx context.Context, req resource.SchemaReque
 	}
 }
 
+// ValidateConfig checks at plan time the constraints between attributes.
+func (r *SendFilesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateOutputConfig(ctx, req.Config)...)
+}
+
 func (r *SendFilesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	// Éviter le panic si le provider n'a pas été configuré
-	if req.ProviderData == nil {
+	meta, diags := conns.FromProviderData(req.ProviderData, "SSM send files resource")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
 		return
 	}
 
-	// Vérifier que la configuration est du bon type
-	config, ok := req.ProviderData.(aws.Config)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Provider configuration error",
-			fmt.Sprintf("Expected aws.Config for SSM send files resource, got: %T. This indicates a provider configuration issue. Please verify your provider configuration and report this issue if it persists.", req.ProviderData),
-		)
-		return
-	}
-	
-	// Créer le client SSM à partir de la configuration AWS
-	r.ssm = ssm.NewFromConfig(config)
+	// Keep the provider metadata to get the SSM client of the resource region
+	r.meta = meta
 }
 
 func (r *SendFilesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -370,13 +381,21 @@ func (r *SendFilesResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	// Execute the common logic for creating/updating the resource
-	data, diag := r.createOrUpdateResource(ctx, data)
+	// Get the command timeout
+	createTimeout, diag := timeouts.Create(data.Timeouts, commandTimeouts)
 	if diag.HasError() {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 
+	// Execute the common logic for creating/updating the resource. Once the command is sent,
+	// the state is saved even on error so that Terraform marks the resource as tainted.
+	data, diag = r.createOrUpdateResource(ctx, data, createTimeout)
+	resp.Diagnostics.Append(diag...)
+	if data.CommandId.IsUnknown() || data.CommandId.IsNull() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -396,6 +415,9 @@ func (r *SendFilesResource) Read(ctx context.Context, req resource.ReadRequest,
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// Update saves the plan without sending the command again: a change of triggers or
+// triggers_replace replaces the resource, and computed values are kept in the plan by
+// the UseStateForUnknown plan modifiers.
 func (r *SendFilesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data SendFilesResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -403,40 +425,6 @@ func (r *SendFilesResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	// Get current state to compare triggers
-	var currentData SendFilesResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &currentData)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	// Check if triggers have changed
-	triggersChanged := !data.Triggers.Equal(currentData.Triggers)
-
-	if triggersChanged {
-		// Execute the common logic for creating/updating the resource
-		var diag diag.Diagnostics
-		data, diag = r.createOrUpdateResource(ctx, data)
-		if diag.HasError() {
-			resp.Diagnostics.Append(diag...)
-			return
-		}
-	} else {
-		// If triggers haven't changed, preserve computed values
-		if data.Id.IsUnknown() || data.Id.IsNull() {
-			data.Id = currentData.Id
-		}
-		if data.CommandId.IsUnknown() || data.CommandId.IsNull() {
-			data.CommandId = currentData.CommandId
-		}
-		if data.Status.IsUnknown() || data.Status.IsNull() {
-			data.Status = currentData.Status
-		}
-	}
-
-	// Ensure computed values are always defined
-	r.ensureComputedValues(&data)
-
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -541,8 +529,8 @@ func (r *SendFilesResource) buildCommands(data SendFilesResourceModel) ([]string
 	return commands, diagnostics
 }
 
-// executeSSMCommand executes an SSM command and handles polling
-func (r *SendFilesResource) executeSSMCommand(ctx context.Context, data SendFilesResourceModel, targets []ssmtypes.Target, commands []string) (SendFilesResourceModel, diag.Diagnostics) {
+// executeSSMCommand executes an SSM command and waits at most timeout for it to complete
+func (r *SendFilesResource) executeSSMCommand(ctx context.Context, data SendFilesResourceModel, targets []ssmtypes.Target, commands []string, timeout time.Duration) (SendFilesResourceModel, diag.Diagnostics) {
 	var diagnostics diag.Diagnostics
 
 	// Get platform runner for document name
@@ -559,12 +547,18 @@ func (r *SendFilesResource) executeSSMCommand(ctx context.Context, data SendFile
 		"commands":         commands,
 	}
 
-	// Send SSM command
-	command, err := r.ssm.SendCommand(ctx, &ssm.SendCommandInput{
+	input := &ssm.SendCommandInput{
 		DocumentName: aws.String(runner.DocumentName()),
 		Targets:      targets,
 		Parameters:   parameters,
-	})
+	}
+
+	// Add the output configuration (S3, CloudWatch Logs)
+	applyOutputConfig(input, data.OutputS3BucketName, data.OutputS3KeyPrefix, data.OutputS3Region, data.CloudWatchOutputConfig)
+
+	// Send SSM command with the client of the resource region
+	conn := r.meta.SSMClient(data.Region.ValueString())
+	command, err := conn.SendCommand(ctx, input)
 	if err != nil {
 		diagnostics.AddError(
 			"Unable to send SSM command",
@@ -575,65 +569,31 @@ func (r *SendFilesResource) executeSSMCommand(ctx context.Context, data SendFile
 
 	data.Id = types.StringValue(*command.Command.CommandId)
 	data.CommandId = types.StringValue(*command.Command.CommandId)
-	data.Status = types.StringValue("InProgress")
-
-	// Polling with timeout
-	createTimeout := 5 * time.Minute
-	ctx, cancel := context.WithTimeout(ctx, createTimeout)
-	defer cancel()
-
-	backoff := time.Second
-	maxAttempts := 10
-	for attempt := 0; attempt < maxAttempts; attempt++ {
-		if ctx.Err() != nil {
-			diagnostics.AddError(
-				"Operation cancelled",
-				fmt.Sprintf("Context cancelled before attempt %d: %s. The operation was interrupted before completion.", attempt+1, ctx.Err()),
-			)
-			return data, diagnostics
-		}
+	data.Status = types.StringValue(commandStatusInProgress)
+	data.Invocations = invocationsNull()
 
-		attemptDiag := PollCommandInvocation(ctx, r.ssm, command)
-		if attemptDiag.HasError() {
-			// fmt.Printf("DEBUG: SSM command failed, setting status to Failed\n")
-			data.Status = types.StringValue("Failed")
-			return data, diagnostics
-		} else if attemptDiag.WarningsCount() > 0 {
-			select {
-			case <-time.After(backoff):
-				backoff *= 2
-				if backoff > 30*time.Second {
-					backoff = 30 * time.Second
-				}
-				continue
-			case <-ctx.Done():
-				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-					diagnostics.AddError(
-						"Timeout while waiting for SSM command to complete",
-						fmt.Sprintf("Timeout occurred while waiting on command '%s' (polled %d times over %s). The command may still be running on the target instances.", *command.Command.CommandId, attempt+1, createTimeout),
-					)
-					return data, diagnostics
-				} else {
-					diagnostics.AddError(
-						"Operation cancelled",
-						fmt.Sprintf("Context cancelled before attempt %d: %s. The operation was interrupted before completion.", attempt+1, ctx.Err()),
-					)
-					return data, diagnostics
-				}
-			}
-		} else {
-			// fmt.Printf("DEBUG: Polling completed, getting actual status\n")
-			actualStatus := getActualCommandStatus(ctx, r.ssm, command)
-			// fmt.Printf("DEBUG: Actual status: %s\n", actualStatus)
-			data.Status = types.StringValue(actualStatus)
-			return data, diagnostics
-		}
+	// Wait for the command to complete
+	result, waitDiags := waitForCommand(ctx, conn, data.CommandId.ValueString(), timeout)
+	diagnostics.Append(waitDiags...)
+	if diagnostics.HasError() {
+		return data, diagnostics
+	}
+
+	data.Status = types.StringValue(result.Status)
+
+	// Record the result of the command on each instance
+	invocations, invocationsDiags := invocationsValue(ctx, conn, data.CommandId.ValueString(), result.Invocations)
+	diagnostics.Append(invocationsDiags...)
+	data.Invocations = invocations
+	if diagnostics.HasError() {
+		return data, diagnostics
+	}
+
+	// Report the command failure according to failure_mode
+	if result.Status != commandStatusSuccess {
+		diagnostics.Append(failuremode.Diagnostics(data.FailureMode, "SSM command failed", commandFailureDetail(data.CommandId.ValueString(), result.Status, result.Invocations))...)
 	}
 
-	// fmt.Printf("DEBUG: Max attempts reached, getting final status\n")
-	actualStatus := getActualCommandStatus(ctx, r.ssm, command)
-	// fmt.Printf("DEBUG: Final status: %s\n", actualStatus)
-	data.Status = types.StringValue(actualStatus)
 	return data, diagnostics
 }
 
@@ -646,7 +606,7 @@ func (r *SendFilesResource) normalizeOptionalValues(data *SendFilesResourceModel
 }
 
 // createOrUpdateResource contains the common logic for creating or updating the resource
-func (r *SendFilesResource) createOrUpdateResource(ctx context.Context, data SendFilesResourceModel) (SendFilesResourceModel, diag.Diagnostics) {
+func (r *SendFilesResource) createOrUpdateResource(ctx context.Context, data SendFilesResourceModel, timeout time.Duration) (SendFilesResourceModel, diag.Diagnostics) {
 	var diagnostics diag.Diagnostics
 
 	// Validate platform
@@ -681,28 +641,13 @@ func (r *SendFilesResource) createOrUpdateResource(ctx context.Context, data Sen
 		return data, diagnostics
 	}
 
-	// Execute SSM command
-	data, diag = r.executeSSMCommand(ctx, data, targets, commands)
-	if diag.HasError() {
-		diagnostics.Append(diag...)
-		return data, diagnostics
-	}
+	// Execute SSM command. Once the command is sent, errors (timeout, failure_mode = "error")
+	// are returned along with the command data so that the state can still be saved.
+	data, diag = r.executeSSMCommand(ctx, data, targets, commands, timeout)
+	diagnostics.Append(diag...)
 
 	// Normalize optional values
 	r.normalizeOptionalValues(&data)
 
 	return data, diagnostics
 }
-
-// ensureComputedValues ensures computed values are always defined
-func (r *SendFilesResource) ensureComputedValues(data *SendFilesResourceModel) {
-	if data.Id.IsUnknown() || data.Id.IsNull() {
-		data.Id = types.StringValue("")
-	}
-	if data.CommandId.IsUnknown() || data.CommandId.IsNull() {
-		data.CommandId = types.StringValue("")
-	}
-	if data.Status.IsUnknown() || data.Status.IsNull() {
-		data.Status = types.StringValue("")
-	}
-}
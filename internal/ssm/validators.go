@@ -0,0 +1,90 @@
+package ssm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// oneOfValidator vérifie au moment du plan qu'un attribut chaîne, ou chaque élément d'un
+// attribut liste, a l'une des valeurs acceptées.
+type oneOfValidator struct {
+	attribute string
+	values    []string
+}
+
+func (v oneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v oneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v oneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	v.validate(req.Path, req.ConfigValue.ValueString(), &resp.Diagnostics)
+}
+
+func (v oneOfValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for i, element := range req.ConfigValue.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		v.validate(req.Path.AtListIndex(i), value.ValueString(), &resp.Diagnostics)
+	}
+}
+
+func (v oneOfValidator) validate(attributePath path.Path, value string, diagnostics *diag.Diagnostics) {
+	if !slices.Contains(v.values, value) {
+		diagnostics.AddAttributeError(
+			attributePath,
+			fmt.Sprintf("Invalid %s", v.attribute),
+			fmt.Sprintf("%s must be one of %s, got '%s'.", v.attribute, strings.Join(v.values, ", "), value),
+		)
+	}
+}
+
+// int64BetweenValidator vérifie au moment du plan qu'un attribut entier est compris entre
+// min et max inclus.
+type int64BetweenValidator struct {
+	attribute string
+	min       int64
+	max       int64
+}
+
+func (v int64BetweenValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be between %d and %d", v.min, v.max)
+}
+
+func (v int64BetweenValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v int64BetweenValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if value := req.ConfigValue.ValueInt64(); value < v.min || value > v.max {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			fmt.Sprintf("Invalid %s", v.attribute),
+			fmt.Sprintf("%s must be between %d and %d, got %d.", v.attribute, v.min, v.max, value),
+		)
+	}
+}
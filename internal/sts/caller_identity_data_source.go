@@ -0,0 +1,165 @@
+package sts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jd-ucpa/terraform-provider-test/internal/conns"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CallerIdentityDataSource{}
+
+// NewCallerIdentityDataSource crée et retourne une nouvelle instance du data source
+// CallerIdentityDataSource. Cette fonction est utilisée par le provider pour enregistrer
+// le data source dans Terraform.
+func NewCallerIdentityDataSource() datasource.DataSource {
+	return &CallerIdentityDataSource{}
+}
+
+// CallerIdentityDataSource expose l'identité effective utilisée par le provider.
+// Ce data source appelle l'API STS GetCallerIdentity avec les credentials résolus
+// (profil, assume_role, web identity, ...) et décompose la session de rôle assumé.
+type CallerIdentityDataSource struct {
+	sts *sts.Client
+}
+
+// CallerIdentityDataSourceModel définit le modèle de données pour le data source CallerIdentity.
+type CallerIdentityDataSourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	AccountId   types.String `tfsdk:"account_id"`
+	Arn         types.String `tfsdk:"arn"`
+	UserId      types.String `tfsdk:"user_id"`
+	RoleArn     types.String `tfsdk:"role_arn"`
+	RoleName    types.String `tfsdk:"role_name"`
+	SessionName types.String `tfsdk:"session_name"`
+}
+
+// Metadata définit le nom du type de data source utilisé dans les configurations Terraform.
+// Ce nom est utilisé pour référencer ce data source dans les fichiers .tf.
+func (d *CallerIdentityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "test_caller_identity"
+}
+
+// Configure initialise le client STS à partir de la configuration du provider.
+// Cette méthode est appelée par Terraform pour configurer le data source avec
+// les paramètres d'authentification AWS (région, credentials, etc.).
+func (d *CallerIdentityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	meta, diags := conns.FromProviderData(req.ProviderData, "caller identity data source")
+	resp.Diagnostics.Append(diags...)
+	if meta == nil {
+		return
+	}
+
+	// Récupérer le client STS partagé par le provider
+	d.sts = meta.STSClient("")
+}
+
+// Schema définit la structure et la documentation du data source.
+// Cette méthode décrit les attributs disponibles, leurs types, et leur documentation Markdown
+// qui sera affichée dans la documentation Terraform.
+func (d *CallerIdentityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `test_caller_identity` data source returns the effective identity used by the provider, as reported by the AWS STS `GetCallerIdentity` API. When the provider assumes a role, the assumed-role session is broken down into `role_arn`, `role_name` and `session_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The AWS account ID (same as account_id).",
+			},
+			"account_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The AWS account ID of the effective identity.",
+			},
+			"arn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ARN of the effective identity (IAM user, assumed-role session, ...).",
+			},
+			"user_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier of the effective identity.",
+			},
+			"role_arn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ARN of the IAM role behind the assumed-role session. Null when the identity is not an assumed role. Role paths are not returned by STS and are therefore not included.",
+			},
+			"role_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the IAM role behind the assumed-role session. Null when the identity is not an assumed role.",
+			},
+			"session_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the assumed-role session. Null when the identity is not an assumed role.",
+			},
+		},
+	}
+}
+
+// Read récupère l'identité effective en appelant l'API STS GetCallerIdentity.
+func (d *CallerIdentityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CallerIdentityDataSourceModel
+
+	// Récupérer la configuration depuis la requête
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Appeler l'API STS GetCallerIdentity
+	output, err := d.sts.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to retrieve caller identity",
+			fmt.Sprintf("Error calling AWS STS GetCallerIdentity API: %s. Please verify your AWS credentials and provider configuration.", err),
+		)
+		return
+	}
+
+	// Remplir le modèle avec l'identité retournée
+	data.Id = types.StringValue(aws.ToString(output.Account))
+	data.AccountId = types.StringValue(aws.ToString(output.Account))
+	data.Arn = types.StringValue(aws.ToString(output.Arn))
+	data.UserId = types.StringValue(aws.ToString(output.UserId))
+	data.RoleArn = types.StringNull()
+	data.RoleName = types.StringNull()
+	data.SessionName = types.StringNull()
+
+	// Décomposer la session de rôle assumé (arn:PARTITION:sts::ACCOUNT_ID:assumed-role/ROLE_NAME/SESSION_NAME)
+	if roleArn, roleName, sessionName, ok := parseAssumedRoleARN(aws.ToString(output.Arn)); ok {
+		data.RoleArn = types.StringValue(roleArn)
+		data.RoleName = types.StringValue(roleName)
+		data.SessionName = types.StringValue(sessionName)
+	}
+
+	// Sauvegarder les données dans l'état
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// parseAssumedRoleARN extrait l'ARN du rôle IAM, le nom du rôle et le nom de session
+// d'un ARN de session STS de rôle assumé. Le dernier résultat est false si l'ARN
+// ne correspond pas à une session de rôle assumé (utilisateur IAM, compte root, ...).
+func parseAssumedRoleARN(value string) (string, string, string, bool) {
+	parsed, err := arn.Parse(value)
+	if err != nil || parsed.Service != "sts" {
+		return "", "", "", false
+	}
+
+	parts := strings.SplitN(parsed.Resource, "/", 3)
+	if len(parts) != 3 || parts[0] != "assumed-role" {
+		return "", "", "", false
+	}
+
+	roleArn := arn.ARN{
+		Partition: parsed.Partition,
+		Service:   "iam",
+		AccountID: parsed.AccountID,
+		Resource:  "role/" + parts[1],
+	}
+	return roleArn.String(), parts[1], parts[2], true
+}
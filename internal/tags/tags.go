@@ -0,0 +1,114 @@
+package tags
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// reservedPrefix est le préfixe réservé par AWS pour les tags système.
+const reservedPrefix = "aws:"
+
+// Les règles de fusion des tags sont déterministes :
+//
+//  1. les tags de la ressource (`tags`) remplacent les tags par défaut (`default_tags`) de même clé ;
+//  2. les clés sont comparées en respectant la casse (`Owner` et `owner` sont deux tags distincts) ;
+//  3. une valeur vide définie dans `tags` remplace quand même la valeur par défaut ;
+//  4. les clés utilisant le préfixe réservé `aws:` sont refusées ;
+//  5. les tags sont toujours envoyés à AWS triés par clé.
+
+// Validate vérifie que les clés de tags ne sont pas vides et n'utilisent pas le préfixe
+// réservé par AWS. attribute est le nom de l'attribut utilisé dans les messages d'erreur.
+func Validate(attribute string, tags map[string]string) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	for _, key := range Keys(tags) {
+		if key == "" {
+			diagnostics.AddError(
+				"Invalid tags configuration",
+				fmt.Sprintf(`"%s" contains an empty tag key. Please provide a non-empty key for every tag.`, attribute),
+			)
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(key), reservedPrefix) {
+			diagnostics.AddError(
+				"Invalid tags configuration",
+				fmt.Sprintf(`"%s.%s" uses the reserved "aws:" prefix. Tag keys starting with "aws:" are reserved for AWS use.`, attribute, key),
+			)
+		}
+	}
+
+	return diagnostics
+}
+
+// Merge fusionne les tags par défaut du provider et les tags d'une ressource.
+// Les tags de la ressource sont prioritaires en cas de clé identique.
+func Merge(defaultTags, resourceTags map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaultTags)+len(resourceTags))
+	for key, value := range defaultTags {
+		merged[key] = value
+	}
+	for key, value := range resourceTags {
+		merged[key] = value
+	}
+	return merged
+}
+
+// Keys retourne les clés des tags triées par ordre alphabétique.
+func Keys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// TagsAll calcule la valeur de l'attribut `tags_all` à partir des tags par défaut
+// du provider et de l'attribut `tags` de la ressource. Le résultat est inconnu
+// tant que les tags de la ressource ne sont pas connus (ex: pendant le plan).
+func TagsAll(ctx context.Context, defaultTags map[string]string, resourceTags types.Map) (types.Map, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	if resourceTags.IsUnknown() {
+		return types.MapUnknown(types.StringType), diagnostics
+	}
+
+	tags := map[string]string{}
+	if !resourceTags.IsNull() {
+		diagnostics.Append(resourceTags.ElementsAs(ctx, &tags, false)...)
+		if diagnostics.HasError() {
+			return types.MapNull(types.StringType), diagnostics
+		}
+	}
+
+	diagnostics.Append(Validate("tags", tags)...)
+	if diagnostics.HasError() {
+		return types.MapNull(types.StringType), diagnostics
+	}
+
+	merged := Merge(defaultTags, tags)
+	elements := make(map[string]attr.Value, len(merged))
+	for key, value := range merged {
+		elements[key] = types.StringValue(value)
+	}
+
+	tagsAll, diags := types.MapValue(types.StringType, elements)
+	diagnostics.Append(diags...)
+	return tagsAll, diagnostics
+}
+
+// TagsAllAttribute retourne l'attribut calculé `tags_all` commun à toutes les ressources taggables.
+func TagsAllAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		ElementType:         types.StringType,
+		Computed:            true,
+		MarkdownDescription: "A map of all tags assigned to the resource, including those inherited from the provider `default_tags` block. Tags defined on the resource override default tags with the same key.",
+	}
+}
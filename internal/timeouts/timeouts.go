@@ -0,0 +1,196 @@
+// Package timeouts fournit le bloc timeouts commun aux ressources dont les opérations
+// peuvent durer longtemps (commandes SSM, builds CodeBuild, exécutions Step Functions, ...).
+//
+// Le bloc suit la syntaxe des autres providers Terraform :
+//
+//	timeouts {
+//	  create = "30m"
+//	}
+//
+// Seules les opérations ayant un délai par défaut non nul dans Defaults sont proposées
+// dans le bloc d'une ressource. Les actions, qui n'ont qu'une seule opération, utilisent
+// à la place un attribut `timeout` (voir ActionAttribute).
+package timeouts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	actionschema "github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Noms des opérations configurables dans le bloc timeouts.
+const (
+	operationCreate = "create"
+	operationUpdate = "update"
+	operationDelete = "delete"
+)
+
+// Defaults définit le délai par défaut de chaque opération d'une ressource.
+// Une opération dont le délai est nul n'est pas proposée dans le bloc timeouts.
+type Defaults struct {
+	Create time.Duration
+	Update time.Duration
+	Delete time.Duration
+}
+
+// Block retourne le bloc timeouts d'une ressource pour les opérations ayant un délai par défaut.
+func Block(defaults Defaults) schema.Block {
+	attributes := make(map[string]schema.Attribute)
+	for operation, defaultTimeout := range defaults.operations() {
+		if defaultTimeout <= 0 {
+			continue
+		}
+		attributes[operation] = schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("How long to wait for the %s operation, as a duration (e.g. `30s`, `20m`, `1h30m`). Defaults to `%s`.", operation, formatDuration(defaultTimeout)),
+			Optional:            true,
+			Validators: []validator.String{
+				durationValidator{},
+			},
+		}
+	}
+
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Configuration block for operation timeouts.",
+		Attributes:          attributes,
+	}
+}
+
+// Create retourne le délai de l'opération create configuré dans le bloc timeouts,
+// ou le délai par défaut si le bloc ou l'attribut n'est pas défini.
+func Create(value types.Object, defaults Defaults) (time.Duration, diag.Diagnostics) {
+	return get(value, operationCreate, defaults.Create)
+}
+
+// Update retourne le délai de l'opération update configuré dans le bloc timeouts,
+// ou le délai par défaut si le bloc ou l'attribut n'est pas défini.
+func Update(value types.Object, defaults Defaults) (time.Duration, diag.Diagnostics) {
+	return get(value, operationUpdate, defaults.Update)
+}
+
+// Delete retourne le délai de l'opération delete configuré dans le bloc timeouts,
+// ou le délai par défaut si le bloc ou l'attribut n'est pas défini.
+func Delete(value types.Object, defaults Defaults) (time.Duration, diag.Diagnostics) {
+	return get(value, operationDelete, defaults.Delete)
+}
+
+// ActionAttribute retourne l'attribut optionnel `timeout` des actions, qui borne la durée
+// de l'invocation.
+func ActionAttribute(defaultTimeout time.Duration) actionschema.StringAttribute {
+	return actionschema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("How long to wait for the action to complete, as a duration (e.g. `30s`, `20m`, `1h30m`). Defaults to `%s`.", formatDuration(defaultTimeout)),
+		Optional:            true,
+		Validators: []validator.String{
+			durationValidator{},
+		},
+	}
+}
+
+// Action retourne le délai configuré dans l'attribut `timeout` d'une action,
+// ou le délai par défaut si l'attribut n'est pas défini.
+func Action(value types.String, defaultTimeout time.Duration) (time.Duration, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() {
+		return defaultTimeout, diagnostics
+	}
+
+	timeout, err := parse(value.ValueString())
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("timeout"),
+			"Invalid timeout configuration",
+			err.Error(),
+		)
+		return defaultTimeout, diagnostics
+	}
+
+	return timeout, diagnostics
+}
+
+// get lit le délai d'une opération dans la valeur du bloc timeouts.
+func get(value types.Object, operation string, defaultTimeout time.Duration) (time.Duration, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() {
+		return defaultTimeout, diagnostics
+	}
+
+	attribute, ok := value.Attributes()[operation].(types.String)
+	if !ok || attribute.IsNull() || attribute.IsUnknown() {
+		return defaultTimeout, diagnostics
+	}
+
+	timeout, err := parse(attribute.ValueString())
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("timeouts").AtName(operation),
+			"Invalid timeouts configuration",
+			err.Error(),
+		)
+		return defaultTimeout, diagnostics
+	}
+
+	return timeout, diagnostics
+}
+
+// operations associe le nom de chaque opération à son délai par défaut.
+func (d Defaults) operations() map[string]time.Duration {
+	return map[string]time.Duration{
+		operationCreate: d.Create,
+		operationUpdate: d.Update,
+		operationDelete: d.Delete,
+	}
+}
+
+// parse convertit une durée du bloc timeouts, qui doit être strictement positive.
+func parse(value string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf(`%q is not a valid timeout. Expected a positive duration such as "30s", "20m" or "1h30m".`, value)
+	}
+	return timeout, nil
+}
+
+// formatDuration affiche une durée sans les unités nulles (ex: "20m" au lieu de "20m0s").
+func formatDuration(duration time.Duration) string {
+	switch {
+	case duration%time.Hour == 0:
+		return fmt.Sprintf("%dh", duration/time.Hour)
+	case duration%time.Minute == 0:
+		return fmt.Sprintf("%dm", duration/time.Minute)
+	default:
+		return duration.String()
+	}
+}
+
+// durationValidator vérifie au moment du plan qu'un délai du bloc timeouts est une durée valide.
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parse(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid timeouts configuration",
+			err.Error(),
+		)
+	}
+}
@@ -0,0 +1,233 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// transportLoadOptions construit les options de retry, de limitation de débit et de
+// transport HTTP du provider. Elles sont appliquées à la configuration AWS partagée,
+// et donc à tous les clients créés par les ressources et data sources.
+func transportLoadOptions(ctx context.Context, config TestProviderModel) ([]func(*awsconfig.LoadOptions) error, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+	var options []func(*awsconfig.LoadOptions) error
+
+	// Retries du SDK : max_retries exclut la tentative initiale
+	if !config.MaxRetries.IsNull() {
+		maxRetries := config.MaxRetries.ValueInt64()
+		if maxRetries < 0 {
+			diagnostics.AddError(
+				"Invalid retry configuration",
+				fmt.Sprintf(`"max_retries" (%d) must be greater than or equal to 0.`, maxRetries),
+			)
+		} else {
+			options = append(options, awsconfig.WithRetryMaxAttempts(int(maxRetries)+1))
+		}
+	}
+	if !config.RetryMode.IsNull() {
+		retryMode := config.RetryMode.ValueString()
+		switch retryMode {
+		case string(aws.RetryModeStandard), string(aws.RetryModeAdaptive):
+			options = append(options, awsconfig.WithRetryMode(aws.RetryMode(retryMode)))
+		default:
+			diagnostics.AddError(
+				"Invalid retry configuration",
+				fmt.Sprintf(`"retry_mode" (%s) must be one of: standard, adaptive.`, retryMode),
+			)
+		}
+	}
+
+	// Limitation de débit côté client, par service
+	if !config.RateLimits.IsNull() {
+		limiters, diags := newRateLimiters(ctx, config.RateLimits)
+		diagnostics.Append(diags...)
+		if len(limiters) > 0 {
+			options = append(options, awsconfig.WithAPIOptions([]func(*middleware.Stack) error{
+				rateLimitMiddleware(limiters),
+			}))
+		}
+	}
+
+	// Bundle de certificats personnalisé (AWS_CA_BUNDLE est géré par le SDK)
+	if !config.CustomCABundle.IsNull() && config.CustomCABundle.ValueString() != "" {
+		path, err := expandHomePath(config.CustomCABundle.ValueString())
+		var pem []byte
+		if err == nil {
+			pem, err = os.ReadFile(path)
+		}
+		if err != nil {
+			diagnostics.AddError(
+				"Invalid custom CA bundle configuration",
+				fmt.Sprintf(`Error reading "custom_ca_bundle" (%s): %s. Please verify the path to the PEM file.`, config.CustomCABundle.ValueString(), err),
+			)
+		} else {
+			options = append(options, awsconfig.WithCustomCABundle(bytes.NewReader(pem)))
+		}
+	}
+
+	// Client HTTP : proxy, timeout des requêtes et vérification TLS
+	var proxyURL *url.URL
+	if !config.HTTPProxy.IsNull() && config.HTTPProxy.ValueString() != "" {
+		parsed, err := url.Parse(config.HTTPProxy.ValueString())
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			diagnostics.AddError(
+				"Invalid HTTP proxy configuration",
+				fmt.Sprintf(`"http_proxy" (%s) is an invalid URL: the proxy must be an absolute http:// or https:// URL.`, config.HTTPProxy.ValueString()),
+			)
+		} else {
+			proxyURL = parsed
+		}
+	}
+
+	var timeout time.Duration
+	if !config.RequestTimeout.IsNull() && config.RequestTimeout.ValueString() != "" {
+		parsed, err := time.ParseDuration(config.RequestTimeout.ValueString())
+		if err != nil || parsed <= 0 {
+			diagnostics.AddError(
+				"Invalid request timeout configuration",
+				fmt.Sprintf(`"request_timeout" (%s) must be a positive duration (e.g. "30s", "2m").`, config.RequestTimeout.ValueString()),
+			)
+		} else {
+			timeout = parsed
+		}
+	}
+
+	if proxyURL != nil || timeout > 0 || config.Insecure.ValueBool() {
+		options = append(options, awsconfig.WithHTTPClient(newHTTPClient(proxyURL, timeout, config.Insecure.ValueBool())))
+	}
+
+	return options, diagnostics
+}
+
+// newHTTPClient retourne le client HTTP utilisé par le SDK AWS. Le proxy remplace
+// les variables d'environnement HTTP_PROXY/HTTPS_PROXY, le timeout s'applique à chaque
+// requête (tentative) et insecure désactive la vérification des certificats TLS,
+// ce qui est destiné aux émulateurs locaux exposés avec un certificat auto-signé.
+func newHTTPClient(proxyURL *url.URL, timeout time.Duration, insecure bool) *awshttp.BuildableClient {
+	client := awshttp.NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+		if proxyURL != nil {
+			tr.Proxy = http.ProxyURL(proxyURL)
+		}
+		if insecure {
+			if tr.TLSClientConfig == nil {
+				tr.TLSClientConfig = &tls.Config{}
+			}
+			tr.TLSClientConfig.InsecureSkipVerify = true
+		}
+	})
+	if timeout > 0 {
+		client = client.WithTimeout(timeout)
+	}
+	return client
+}
+
+// rateLimiter espace les requêtes d'un service afin de ne pas dépasser un nombre
+// de requêtes par seconde. Les requêtes en excès attendent leur créneau au lieu
+// d'être rejetées, ce qui évite le throttling côté AWS dans les gros comptes.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// wait bloque jusqu'au prochain créneau disponible ou jusqu'à l'annulation du contexte.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// newRateLimiters convertit l'attribut rate_limits (requêtes par seconde indexées par
+// nom de service) en limiteurs indexés par ServiceID du SDK AWS.
+func newRateLimiters(ctx context.Context, rateLimits types.Map) (map[string]*rateLimiter, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+
+	values := map[string]float64{}
+	diagnostics.Append(rateLimits.ElementsAs(ctx, &values, false)...)
+	if diagnostics.HasError() {
+		return nil, diagnostics
+	}
+
+	limiters := map[string]*rateLimiter{}
+	for service, requestsPerSecond := range values {
+		serviceID, ok := serviceIDs[service]
+		if !ok {
+			names := make([]string, 0, len(serviceIDs))
+			for name := range serviceIDs {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+			diagnostics.AddError(
+				"Invalid rate limit configuration",
+				fmt.Sprintf(`"rate_limits.%s" is not a supported service. Supported services are: %s.`, service, strings.Join(names, ", ")),
+			)
+			continue
+		}
+		if requestsPerSecond <= 0 {
+			diagnostics.AddError(
+				"Invalid rate limit configuration",
+				fmt.Sprintf(`"rate_limits.%s" (%g) must be a positive number of requests per second.`, service, requestsPerSecond),
+			)
+			continue
+		}
+
+		limiters[serviceID] = &rateLimiter{
+			interval: time.Duration(float64(time.Second) / requestsPerSecond),
+		}
+	}
+
+	return limiters, diagnostics
+}
+
+// rateLimitMiddleware ajoute à chaque client un middleware qui attend le créneau du
+// limiteur de son service avant d'envoyer la requête. Il est placé après le middleware
+// de retry afin que chaque tentative soit comptabilisée.
+func rateLimitMiddleware(limiters map[string]*rateLimiter) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("TestProviderRateLimit", func(
+			ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler,
+		) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			if limiter, ok := limiters[awsmiddleware.GetServiceID(ctx)]; ok {
+				if err := limiter.wait(ctx); err != nil {
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+				}
+			}
+			return next.HandleFinalize(ctx, in)
+		}), middleware.After)
+	}
+}
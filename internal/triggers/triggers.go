@@ -0,0 +1,41 @@
+// Package triggers fournit les attributs triggers et triggers_replace communs aux ressources
+// qui lancent une opération (commande SSM, build CodeBuild, exécution Step Functions).
+//
+// Un changement de l'un de ces attributs entraîne le remplacement de la ressource, c'est-à-dire
+// une nouvelle exécution de l'opération. Les autres modifications sont appliquées sur place
+// sans relancer l'opération : les valeurs calculées sont alors conservées dans le plan par les
+// plan modifiers UseStateForUnknown des ressources.
+package triggers
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/dynamicplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ResourceAttribute retourne l'attribut optionnel `triggers` des ressources.
+func ResourceAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		ElementType:         types.StringType,
+		MarkdownDescription: "A map of arbitrary strings that, when changed, will force the resource to be replaced, which runs it again.",
+		Optional:            true,
+		PlanModifiers: []planmodifier.Map{
+			mapplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// ReplaceResourceAttribute retourne l'attribut optionnel `triggers_replace` des ressources.
+// Contrairement à `triggers`, il accepte une valeur de n'importe quel type (chaîne, liste,
+// objet, ...), ce qui permet de référencer directement d'autres ressources.
+func ReplaceResourceAttribute() schema.DynamicAttribute {
+	return schema.DynamicAttribute{
+		MarkdownDescription: "A value of any type (string, number, list, map, object, ...) that, when changed, will force the resource to be replaced, which runs it again. Unlike `triggers`, other resources can be referenced directly, e.g. `triggers_replace = [aws_instance.web.id, filesha256(\"script.sh\")]`.",
+		Optional:            true,
+		PlanModifiers: []planmodifier.Dynamic{
+			dynamicplanmodifier.RequiresReplace(),
+		},
+	}
+}
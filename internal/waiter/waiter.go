@@ -0,0 +1,203 @@
+// Package waiter fournit une boucle d'attente commune aux ressources qui lancent une
+// opération AWS puis attendent son résultat (commandes SSM, builds CodeBuild, ...).
+//
+// L'état de l'opération est lu par une RefreshFunc et classé selon trois listes :
+// les états d'attente (Pending), les états finaux attendus (Target) et les états
+// d'échec (Failure). Entre deux lectures, le délai croît exponentiellement de MinDelay
+// à MaxDelay, avec une variation aléatoire (Jitter) pour éviter que des ressources
+// lancées en parallèle n'interrogent l'API au même moment.
+package waiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Valeurs par défaut utilisées lorsque les champs correspondants de Config ne sont pas définis.
+const (
+	DefaultTimeout  = 5 * time.Minute
+	DefaultMinDelay = time.Second
+	DefaultMaxDelay = 30 * time.Second
+	DefaultJitter   = 0.1
+)
+
+// RefreshFunc lit l'état courant de l'opération. Le résultat retourné est transmis
+// tel quel à l'appelant de Wait lorsque l'attente se termine. Une erreur interrompt
+// immédiatement l'attente.
+type RefreshFunc[T any] func(ctx context.Context) (result T, state string, err error)
+
+// Config décrit une attente : l'opération suivie, les états possibles et la cadence
+// des lectures.
+type Config[T any] struct {
+	// Operation décrit l'opération dans les logs et les messages d'erreur
+	// (ex: "SSM command 'abc-123'").
+	Operation string
+
+	// Pending liste les états pour lesquels l'attente continue.
+	Pending []string
+	// Target liste les états qui terminent l'attente avec succès.
+	Target []string
+	// Failure liste les états qui terminent l'attente en erreur (FailureError).
+	Failure []string
+
+	// Refresh lit l'état courant de l'opération.
+	Refresh RefreshFunc[T]
+
+	// Timeout est la durée maximale de l'attente. DefaultTimeout si nul.
+	Timeout time.Duration
+	// MinDelay est le délai avant la deuxième lecture. DefaultMinDelay si nul.
+	MinDelay time.Duration
+	// MaxDelay est le délai maximal entre deux lectures. DefaultMaxDelay si nul.
+	MaxDelay time.Duration
+	// Jitter est la variation aléatoire appliquée à chaque délai, en fraction
+	// du délai (0.1 = ±10 %). DefaultJitter si nul, aucune variation si négatif.
+	Jitter float64
+}
+
+// TimeoutError est retournée lorsque l'opération n'a pas atteint un état final
+// avant la fin du délai.
+type TimeoutError struct {
+	Operation string
+	LastState string
+	Timeout   time.Duration
+	Attempts  int
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timeout while waiting for %s (last state %q, polled %d times over %s)", e.Operation, e.LastState, e.Attempts, e.Timeout)
+}
+
+// FailureError est retournée lorsque l'opération atteint un état d'échec.
+type FailureError struct {
+	Operation string
+	State     string
+}
+
+func (e *FailureError) Error() string {
+	return fmt.Sprintf("%s reached failure state %q", e.Operation, e.State)
+}
+
+// UnexpectedStateError est retournée lorsque l'opération atteint un état qui
+// n'appartient à aucune des listes de la configuration.
+type UnexpectedStateError struct {
+	Operation string
+	State     string
+	Expected  []string
+}
+
+func (e *UnexpectedStateError) Error() string {
+	return fmt.Sprintf("unexpected state %q for %s, wanted one of: %s", e.State, e.Operation, strings.Join(e.Expected, ", "))
+}
+
+// Wait lit l'état de l'opération jusqu'à ce qu'il atteigne un état de Target ou de Failure,
+// que le délai expire ou que le contexte soit annulé. Le dernier résultat lu est toujours
+// retourné, y compris en cas d'erreur, afin que l'appelant puisse enregistrer l'état atteint.
+func Wait[T any](ctx context.Context, config Config[T]) (T, error) {
+	timeout := valueOrDefault(config.Timeout, DefaultTimeout)
+	minDelay := valueOrDefault(config.MinDelay, DefaultMinDelay)
+	maxDelay := max(valueOrDefault(config.MaxDelay, DefaultMaxDelay), minDelay)
+	jitter := config.Jitter
+	if jitter == 0 {
+		jitter = DefaultJitter
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ctx = tflog.SetField(ctx, "operation", config.Operation)
+	tflog.Info(ctx, "Waiting for operation to complete", map[string]any{
+		"timeout": timeout.String(),
+		"target":  config.Target,
+	})
+
+	var result T
+	var state string
+	delay := minDelay
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		var err error
+		result, state, err = config.Refresh(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return result, contextError(ctx, config.Operation, state, timeout, attempt)
+			}
+			return result, err
+		}
+
+		switch {
+		case slices.Contains(config.Target, state):
+			tflog.Info(ctx, "Operation completed", map[string]any{
+				"state":    state,
+				"attempts": attempt,
+				"elapsed":  time.Since(start).Round(time.Millisecond).String(),
+			})
+			return result, nil
+		case slices.Contains(config.Failure, state):
+			tflog.Warn(ctx, "Operation failed", map[string]any{
+				"state":    state,
+				"attempts": attempt,
+			})
+			return result, &FailureError{Operation: config.Operation, State: state}
+		case !slices.Contains(config.Pending, state):
+			return result, &UnexpectedStateError{
+				Operation: config.Operation,
+				State:     state,
+				Expected:  slices.Concat(config.Pending, config.Target, config.Failure),
+			}
+		}
+
+		wait := withJitter(delay, jitter)
+		tflog.Debug(ctx, "Operation still in progress", map[string]any{
+			"state":      state,
+			"attempt":    attempt,
+			"next_delay": wait.Round(time.Millisecond).String(),
+		})
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return result, contextError(ctx, config.Operation, state, timeout, attempt)
+		}
+
+		delay = min(delay*2, maxDelay)
+	}
+}
+
+// contextError convertit la fin du contexte en TimeoutError lorsque le délai de
+// l'attente a expiré, ou retourne l'erreur d'annulation telle quelle.
+func contextError(ctx context.Context, operation, state string, timeout time.Duration, attempts int) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &TimeoutError{
+			Operation: operation,
+			LastState: state,
+			Timeout:   timeout,
+			Attempts:  attempts,
+		}
+	}
+	return fmt.Errorf("waiting for %s: %w", operation, ctx.Err())
+}
+
+// withJitter applique une variation aléatoire de ±jitter au délai.
+func withJitter(delay time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 {
+		return delay
+	}
+	jitter = min(jitter, 1)
+	return time.Duration(float64(delay) * (1 + jitter*(2*rand.Float64()-1)))
+}
+
+// valueOrDefault retourne value si elle est strictement positive, sinon defaultValue.
+func valueOrDefault(value, defaultValue time.Duration) time.Duration {
+	if value > 0 {
+		return value
+	}
+	return defaultValue
+}
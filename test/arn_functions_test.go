@@ -0,0 +1,143 @@
+package test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccARNParseFunction_Basic teste la décomposition d'un ARN régional, d'un ARN
+// global (IAM) et d'un ARN de policy gérée par AWS par la fonction provider::test::arn_parse.
+func TestAccARNParseFunction_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  state_machine = provider::test::arn_parse("arn:aws:states:eu-west-1:123456789012:stateMachine:my-state-machine")
+  role          = provider::test::arn_parse("arn:aws-cn:iam::123456789012:role/my-role")
+  policy        = provider::test::arn_parse("arn:aws:iam::aws:policy/ReadOnlyAccess")
+}
+
+output "partition" {
+  value = local.state_machine.partition
+}
+
+output "service" {
+  value = local.state_machine.service
+}
+
+output "region" {
+  value = local.state_machine.region
+}
+
+output "account_id" {
+  value = local.state_machine.account_id
+}
+
+output "resource" {
+  value = local.state_machine.resource
+}
+
+output "role_region" {
+  value = local.role.region
+}
+
+output "role_partition" {
+  value = local.role.partition
+}
+
+output "policy_account_id" {
+  value = local.policy.account_id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("partition", "aws"),
+					resource.TestCheckOutput("service", "states"),
+					resource.TestCheckOutput("region", "eu-west-1"),
+					resource.TestCheckOutput("account_id", "123456789012"),
+					resource.TestCheckOutput("resource", "stateMachine:my-state-machine"),
+					resource.TestCheckOutput("role_region", ""),
+					resource.TestCheckOutput("role_partition", "aws-cn"),
+					resource.TestCheckOutput("policy_account_id", "aws"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccARNParseFunction_Invalid teste les erreurs retournées pour un ARN mal formé
+// et pour un ARN dont la région n'appartient pas à la partition.
+func TestAccARNParseFunction_Invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "invalid" {
+  value = provider::test::arn_parse("not-an-arn")
+}
+`,
+				ExpectError: regexp.MustCompile(`"not-an-arn" is an invalid ARN`),
+			},
+			{
+				Config: `
+output "invalid" {
+  value = provider::test::arn_parse("arn:aws:states:cn-north-1:123456789012:stateMachine:x")
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid region value \(expected a region of the aws\s+partition\)`),
+			},
+		},
+	})
+}
+
+// TestAccARNBuildFunction_Basic teste la construction d'ARN régionaux et globaux
+// par la fonction provider::test::arn_build.
+func TestAccARNBuildFunction_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "state_machine_arn" {
+  value = provider::test::arn_build("aws", "states", "eu-west-1", "123456789012", "stateMachine:my-state-machine")
+}
+
+output "role_arn" {
+  value = provider::test::arn_build("aws-us-gov", "iam", null, "123456789012", "role/my-role")
+}
+
+output "bucket_arn" {
+  value = provider::test::arn_build("aws", "s3", "", "", "my-bucket")
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("state_machine_arn", "arn:aws:states:eu-west-1:123456789012:stateMachine:my-state-machine"),
+					resource.TestCheckOutput("role_arn", "arn:aws-us-gov:iam::123456789012:role/my-role"),
+					resource.TestCheckOutput("bucket_arn", "arn:aws:s3:::my-bucket"),
+				),
+			},
+			{
+				Config: `
+output "invalid" {
+  value = provider::test::arn_build("aws", "iam", null, "1234", "role/my-role")
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid account ID value`),
+			},
+		},
+	})
+}
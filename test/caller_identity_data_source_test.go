@@ -0,0 +1,71 @@
+package test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccCallerIdentityDataSource_Basic teste la récupération de l'identité effective du provider.
+// Ce test configure le provider avec le profil AWS_PROFILE, utilise le data source Caller Identity
+// puis vérifie que l'identifiant de compte, l'ARN et l'identifiant utilisateur sont retournés.
+func TestAccCallerIdentityDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+					}
+
+					data "test_caller_identity" "current" {}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.test_caller_identity.current", "id"),
+					resource.TestMatchResourceAttr("data.test_caller_identity.current", "account_id", regexp.MustCompile(`^\d{12}$`)),
+					resource.TestMatchResourceAttr("data.test_caller_identity.current", "arn", regexp.MustCompile(`^arn:aws:`)),
+					resource.TestCheckResourceAttrSet("data.test_caller_identity.current", "user_id"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccCallerIdentityDataSource_AssumeRole teste la décomposition d'une session de rôle assumé.
+// Ce test configure le provider avec le profil AWS_PROFILE et un bloc assume_role utilisant ROLE_ARN
+// avec un nom de session explicite, puis vérifie que role_arn, role_name et session_name
+// correspondent au rôle assumé.
+func TestAccCallerIdentityDataSource_AssumeRole(t *testing.T) {
+	roleArn := getVar("ROLE_ARN")
+	roleName := roleArn[strings.LastIndex(roleArn, "/")+1:]
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+						assume_role {
+							role_arn     = "` + roleArn + `"
+							session_name = "caller-identity-test"
+						}
+					}
+
+					data "test_caller_identity" "current" {}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.test_caller_identity.current", "account_id", strings.Split(roleArn, ":")[4]),
+					resource.TestCheckResourceAttr("data.test_caller_identity.current", "role_name", roleName),
+					resource.TestCheckResourceAttr("data.test_caller_identity.current", "session_name", "caller-identity-test"),
+					resource.TestMatchResourceAttr("data.test_caller_identity.current", "arn", regexp.MustCompile(`:assumed-role/`+regexp.QuoteMeta(roleName)+`/caller-identity-test$`)),
+				),
+			},
+		},
+	})
+}
@@ -234,6 +234,55 @@ func TestAccCloudWatchAlarmsDataSource_InvalidStateValue(t *testing.T) {
 	})
 }
 
+// TestAccCloudWatchAlarmsDataSource_WithRegion teste la surcharge de la région du provider
+// par le data source : les alarmes sont lues dans la région us-east-1 avec les credentials du provider.
+func TestAccCloudWatchAlarmsDataSource_WithRegion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region  = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE_OTHER") + `"
+					}
+
+					data "test_cloudwatch_alarms" "alarms" {
+						alarm_type = "MetricAlarm"
+						region     = "us-east-1"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.test_cloudwatch_alarms.alarms", "id"),
+					resource.TestCheckResourceAttr("data.test_cloudwatch_alarms.alarms", "region", "us-east-1"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccCloudWatchAlarmsDataSource_InvalidRegion teste qu'une région inconnue est refusée
+// avant tout appel à l'API CloudWatch.
+func TestAccCloudWatchAlarmsDataSource_InvalidRegion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						profile = "` + getVar("AWS_PROFILE_OTHER") + `"
+					}
+
+					data "test_cloudwatch_alarms" "alarms" {
+						region = "eu-middle-9"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`invalid AWS Region: eu-middle-9`),
+			},
+		},
+	})
+}
+
 // checkMinAlarmsCount crée une fonction de vérification pour un nombre minimum d'alarmes
 func checkMinAlarmsCount(minCount int) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
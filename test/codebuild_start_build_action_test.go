@@ -0,0 +1,95 @@
+package test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccCodeBuildStartBuildAction_Basic teste l'invocation de l'action test_codebuild_start_build.
+// L'action est déclenchée à la création d'une ressource terraform_data : l'apply réussit
+// si le build s'est terminé avec succès.
+func TestAccCodeBuildStartBuildAction_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion1_14_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+					}
+
+					action "test_codebuild_start_build" "test" {
+						config {
+							project_name = "` + getVar("CODEBUILD_PROJECT_NAME") + `"
+
+							environment_variables {
+								name  = "TEST_VAR"
+								value = "test_value"
+							}
+						}
+					}
+
+					resource "terraform_data" "test" {
+						lifecycle {
+							action_trigger {
+								events  = [after_create]
+								actions = [action.test_codebuild_start_build.test]
+							}
+						}
+					}
+				`,
+				Check: resource.TestCheckResourceAttrSet("terraform_data.test", "id"),
+			},
+		},
+	})
+}
+
+// TestAccCodeBuildStartBuildAction_InvalidParameterStore teste que l'action vérifie
+// l'existence des paramètres PARAMETER_STORE avant de démarrer le build.
+func TestAccCodeBuildStartBuildAction_InvalidParameterStore(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion1_14_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+					}
+
+					action "test_codebuild_start_build" "test" {
+						config {
+							project_name = "` + getVar("CODEBUILD_PROJECT_NAME") + `"
+
+							environment_variables {
+								name  = "INVALID_PARAM"
+								value = "/nonexistent/parameter"
+								type  = "PARAMETER_STORE"
+							}
+						}
+					}
+
+					resource "terraform_data" "test" {
+						lifecycle {
+							action_trigger {
+								events  = [after_create]
+								actions = [action.test_codebuild_start_build.test]
+							}
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Parameter Store parameter '/nonexistent/parameter' does not exist`),
+			},
+		},
+	})
+}
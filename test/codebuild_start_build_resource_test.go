@@ -35,6 +35,8 @@ func TestAccCodeBuildStartBuildResource_Basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet("test_codebuild_start_build.test", "build_arn"),
 					resource.TestCheckResourceAttrSet("test_codebuild_start_build.test", "build_number"),
 					resource.TestCheckResourceAttr("test_codebuild_start_build.test", "build_project_name", getVar("CODEBUILD_PROJECT_NAME")),
+					resource.TestCheckResourceAttr("test_codebuild_start_build.test", "wait_for_completion", "false"),
+					resource.TestCheckResourceAttr("test_codebuild_start_build.test", "build_status", "IN_PROGRESS"),
 				),
 			},
 		},
@@ -651,3 +653,61 @@ func TestAccCodeBuildStartBuildResource_TriggersBehavior(t *testing.T) {
 		},
 	})
 }
+
+// TestAccCodeBuildStartBuildResource_WaitForCompletion teste l'attente de la fin d'un build CodeBuild.
+// Ce test démarre un build avec wait_for_completion = true, puis vérifie que le statut enregistré
+// est un statut final et non IN_PROGRESS.
+func TestAccCodeBuildStartBuildResource_WaitForCompletion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+					}
+
+					resource "test_codebuild_start_build" "test" {
+						project_name        = "` + getVar("CODEBUILD_PROJECT_NAME") + `"
+						wait_for_completion = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("test_codebuild_start_build.test", "build_id"),
+					resource.TestCheckResourceAttr("test_codebuild_start_build.test", "wait_for_completion", "true"),
+					resource.TestMatchResourceAttr("test_codebuild_start_build.test", "build_status", regexp.MustCompile(`^(SUCCEEDED|FAILED|FAULT|TIMED_OUT|STOPPED)$`)),
+				),
+			},
+		},
+	})
+}
+
+// TestAccCodeBuildStartBuildResource_WaitForCompletionTimeout teste que l'attente de la fin du build
+// respecte le délai du bloc timeouts : un build plus long que le délai create fait échouer l'apply,
+// et le diagnostic indique le dernier statut lu et le nombre de lectures effectuées.
+func TestAccCodeBuildStartBuildResource_WaitForCompletionTimeout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+					}
+
+					resource "test_codebuild_start_build" "test" {
+						project_name        = "` + getVar("CODEBUILD_PROJECT_NAME") + `"
+						wait_for_completion = true
+
+						timeouts {
+							create = "10s"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?s)Unable to wait for CodeBuild build to complete.*timeout while waiting for CodeBuild build.*last state "IN_PROGRESS", polled \d+ times over 10s`),
+			},
+		},
+	})
+}
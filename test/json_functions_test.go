@@ -0,0 +1,96 @@
+package test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccJSONPrettyFunction_Basic teste la fonction provider::test::json_pretty :
+// l'ordre des clés du document d'origine doit être conservé.
+func TestAccJSONPrettyFunction_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "pretty" {
+  value = provider::test::json_pretty("{\"name\":\"John\",\"age\":30}", 2)
+}
+
+output "pretty_4" {
+  value = provider::test::json_pretty("{\"name\":\"John\",\"age\":30}", 4)
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("pretty", "{\n  \"name\": \"John\",\n  \"age\": 30\n}"),
+					resource.TestCheckOutput("pretty_4", "{\n    \"name\": \"John\",\n    \"age\": 30\n}"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccJSONPrettyFunction_InvalidArguments teste les erreurs retournées pour un JSON
+// invalide et pour une indentation hors limites.
+func TestAccJSONPrettyFunction_InvalidArguments(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "invalid" {
+  value = provider::test::json_pretty("{\"name\":", 2)
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to parse JSON string`),
+			},
+			{
+				Config: `
+output "invalid" {
+  value = provider::test::json_pretty("{}", -1)
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid indent value: -1`),
+			},
+		},
+	})
+}
+
+// TestAccJSONMinifyFunction_Basic teste la fonction provider::test::json_minify.
+func TestAccJSONMinifyFunction_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "minified" {
+  value = provider::test::json_minify("{\n  \"name\": \"John\",\n  \"tags\": [ \"a\", \"b\" ]\n}")
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("minified", `{"name":"John","tags":["a","b"]}`),
+				),
+			},
+			{
+				Config: `
+output "minified" {
+  value = provider::test::json_minify("not json")
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to parse JSON string`),
+			},
+		},
+	})
+}
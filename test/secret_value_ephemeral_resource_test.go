@@ -0,0 +1,119 @@
+package test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccSecretValueEphemeralResource_Secret teste la lecture d'un secret Secrets Manager.
+// Le résultat de la ressource éphémère est transmis au provider echo afin de vérifier
+// que la valeur et l'ARN du secret SECRET_NAME sont renseignés.
+func TestAccSecretValueEphemeralResource_Secret(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE_OTHER_AGAIN") + `"
+					}
+
+					ephemeral "test_secret_value" "test" {
+						secret_id = "` + getVar("SECRET_NAME") + `"
+					}
+
+					provider "echo" {
+						data = ephemeral.test_secret_value.test
+					}
+
+					resource "echo" "test" {}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("echo.test", "data.arn"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.value"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.version_id"),
+					resource.TestCheckNoResourceAttr("echo.test", "data.values"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccSecretValueEphemeralResource_Parameter teste la lecture d'un paramètre
+// Parameter Store (CODEBUILD_PARAMETER_NAME) avec déchiffrement.
+func TestAccSecretValueEphemeralResource_Parameter(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+						assume_role {
+							role_arn = "` + getVar("ROLE_ARN") + `"
+						}
+					}
+
+					ephemeral "test_secret_value" "test" {
+						parameter_name = "` + getVar("CODEBUILD_PARAMETER_NAME") + `"
+					}
+
+					provider "echo" {
+						data = ephemeral.test_secret_value.test
+					}
+
+					resource "echo" "test" {}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("echo.test", "data.arn"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.value"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.version_id"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccSecretValueEphemeralResource_Validation teste qu'exactement une source
+// (secret_id ou parameter_name) doit être spécifiée.
+func TestAccSecretValueEphemeralResource_Validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE_OTHER_AGAIN") + `"
+					}
+
+					ephemeral "test_secret_value" "test" {
+						secret_id      = "` + getVar("SECRET_NAME") + `"
+						parameter_name = "` + getVar("CODEBUILD_PARAMETER_NAME") + `"
+					}
+
+					provider "echo" {
+						data = ephemeral.test_secret_value.test
+					}
+
+					resource "echo" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`Exactly one of secret_id or parameter_name must be specified`),
+			},
+		},
+	})
+}
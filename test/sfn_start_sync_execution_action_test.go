@@ -0,0 +1,84 @@
+package test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccSFNStartSyncExecutionAction_Basic teste l'invocation de l'action
+// test_sfn_start_sync_execution. L'action est déclenchée à la création d'une ressource
+// terraform_data : l'apply réussit si l'exécution s'est terminée avec succès.
+func TestAccSFNStartSyncExecutionAction_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion1_14_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						profile = "` + getVar("AWS_PROFILE") + `"
+					}
+
+					action "test_sfn_start_sync_execution" "test" {
+						config {
+							state_machine_arn = "` + getVar("STATE_MACHINE_ARN") + `"
+							input             = jsonencode({ key = "value" })
+						}
+					}
+
+					resource "terraform_data" "test" {
+						lifecycle {
+							action_trigger {
+								events  = [after_create]
+								actions = [action.test_sfn_start_sync_execution.test]
+							}
+						}
+					}
+				`,
+				Check: resource.TestCheckResourceAttrSet("terraform_data.test", "id"),
+			},
+		},
+	})
+}
+
+// TestAccSFNStartSyncExecutionAction_InvalidStateMachineArn vérifie que l'ARN de la state
+// machine de l'action est validé au moment du plan, comme celui de la ressource.
+func TestAccSFNStartSyncExecutionAction_InvalidStateMachineArn(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion1_14_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+					}
+
+					action "test_sfn_start_sync_execution" "test" {
+						config {
+							state_machine_arn = "arn:aws-cn:states:eu-west-1:123456789012:stateMachine:example"
+						}
+					}
+
+					resource "terraform_data" "test" {
+						lifecycle {
+							action_trigger {
+								events  = [after_create]
+								actions = [action.test_sfn_start_sync_execution.test]
+							}
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`invalid region value \(expected a region of the aws-cn\s+partition\)`),
+			},
+		},
+	})
+}
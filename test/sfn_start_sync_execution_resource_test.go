@@ -2,6 +2,7 @@ package test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -433,3 +434,27 @@ func TestAccSFNStartSyncExecutionResource_TriggersBehavior(t *testing.T) {
 		},
 	})
 }
+
+// TestAccSFNStartSyncExecutionResource_InvalidStateMachineArn vérifie que l'ARN de la state machine
+// est validé au moment du plan : un ARN dont la région n'appartient pas à la partition déclarée
+// (ici "arn:aws-cn:states:eu-west-1:...") doit être rejeté sans appeler l'API Step Functions.
+func TestAccSFNStartSyncExecutionResource_InvalidStateMachineArn(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+					}
+
+					resource "test_sfn_start_sync_execution" "test" {
+						state_machine_arn = "arn:aws-cn:states:eu-west-1:123456789012:stateMachine:example"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`invalid region value \(expected a region of the aws-cn\s+partition\)`),
+			},
+		},
+	})
+}
@@ -0,0 +1,92 @@
+package test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// testAccProtoV6ProviderFactoriesWithEcho ajoute le provider echo aux providers de test.
+// Le provider echo recopie dans l'état une valeur éphémère reçue dans sa configuration,
+// ce qui permet de vérifier le résultat d'une ressource éphémère.
+var testAccProtoV6ProviderFactoriesWithEcho = map[string]func() (tfprotov6.ProviderServer, error){
+	"test": testAccProtoV6ProviderFactories["test"],
+	"echo": echoprovider.NewProviderServer(),
+}
+
+// TestAccSSMActivationEphemeralResource_Basic teste la création d'une activation SSM éphémère.
+// Ce test ouvre la ressource éphémère, transmet son résultat au provider echo et vérifie que
+// l'identifiant, le code et la date d'expiration de l'activation sont renseignés.
+func TestAccSSMActivationEphemeralResource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE_OTHER_AGAIN") + `"
+					}
+
+					ephemeral "test_ssm_activation" "test" {
+						iam_role    = "` + getVar("ACTIVATION_ROLE_NAME") + `"
+						description = "Test SSM ephemeral activation from Terraform provider"
+						expires_in  = "15m"
+					}
+
+					provider "echo" {
+						data = ephemeral.test_ssm_activation.test
+					}
+
+					resource "echo" "test" {}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("echo.test", "data.activation_id"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.activation_code"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.expiration_date"),
+					resource.TestCheckResourceAttr("echo.test", "data.iam_role", getVar("ACTIVATION_ROLE_NAME")),
+				),
+			},
+		},
+	})
+}
+
+// TestAccSSMActivationEphemeralResource_InvalidExpiresIn teste qu'une durée de validité
+// supérieure à 30 jours est refusée avant la création de l'activation.
+func TestAccSSMActivationEphemeralResource_InvalidExpiresIn(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE_OTHER_AGAIN") + `"
+					}
+
+					ephemeral "test_ssm_activation" "test" {
+						iam_role   = "` + getVar("ACTIVATION_ROLE_NAME") + `"
+						expires_in = "1000h"
+					}
+
+					provider "echo" {
+						data = ephemeral.test_ssm_activation.test
+					}
+
+					resource "echo" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`must be a positive duration of at most 30 days`),
+			},
+		},
+	})
+}
@@ -7,9 +7,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
-// TestAccSSMActivationResource_ValidationError teste la gestion d'erreur avec un rôle IAM qui ne satisfait pas les contraintes SSM.
-// Ce test configure le provider avec assume_role, tente de créer une ressource SSM Activation avec
-// un rôle IAM qui ne satisfait pas le pattern regex de l'API SSM, et vérifie que l'erreur est correctement gérée.
+// TestAccSSMActivationResource_ValidationError teste la gestion d'erreur avec un rôle IAM invalide.
+// Ce test tente de créer une ressource SSM Activation avec un nom de rôle contenant des espaces,
+// puis avec un ARN IAM qui n'est pas celui d'un rôle, et vérifie que les deux valeurs sont refusées
+// dès le plan, avant tout appel à l'API SSM.
 func TestAccSSMActivationResource_ValidationError(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -27,7 +28,22 @@ func TestAccSSMActivationResource_ValidationError(t *testing.T) {
 						description = "Test SSM activation with validation error"
 					}
 				`,
-				ExpectError: regexp.MustCompile(`ValidationException`),
+				ExpectError: regexp.MustCompile(`Invalid IAM role`),
+			},
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE_OTHER_AGAIN") + `"
+					}
+					
+					resource "test_ssm_activation" "test" {
+						iam_role = "arn:aws:iam::123456789012:user/ssm"
+						secret_name = "` + getVar("SECRET_NAME") + `"
+						description = "Test SSM activation with validation error"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?s)Invalid IAM role.*invalid\s+role\s+name\s+value`),
 			},
 		},
 	})
@@ -477,3 +493,102 @@ func TestAccSSMActivationResource_UpdateManaged(t *testing.T) {
 		},
 	})
 }
+
+// TestAccSSMActivationResource_WithDefaultTags teste la fusion des default_tags du provider
+// avec les tags de l'activation SSM. Ce test vérifie que tags_all contient les tags par défaut,
+// que les tags de la ressource remplacent les tags par défaut de même clé, puis qu'une modification
+// des default_tags seuls est bien reportée dans tags_all.
+func TestAccSSMActivationResource_WithDefaultTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE_OTHER_AGAIN") + `"
+						default_tags {
+							tags = {
+								CostCenter = "platform"
+								Owner      = "default-team"
+							}
+						}
+					}
+
+					resource "test_ssm_activation" "test" {
+						iam_role = "` + getVar("ACTIVATION_ROLE_NAME") + `"
+						secret_name = "` + getVar("SECRET_NAME") + `"
+						description = "Test SSM activation with default tags"
+
+						tags = {
+							Owner = "test-team"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("test_ssm_activation.test", "tags.%", "1"),
+					resource.TestCheckResourceAttr("test_ssm_activation.test", "tags_all.%", "2"),
+					resource.TestCheckResourceAttr("test_ssm_activation.test", "tags_all.CostCenter", "platform"),
+					resource.TestCheckResourceAttr("test_ssm_activation.test", "tags_all.Owner", "test-team"),
+				),
+			},
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE_OTHER_AGAIN") + `"
+						default_tags {
+							tags = {
+								CostCenter = "finance"
+								Owner      = "default-team"
+							}
+						}
+					}
+
+					resource "test_ssm_activation" "test" {
+						iam_role = "` + getVar("ACTIVATION_ROLE_NAME") + `"
+						secret_name = "` + getVar("SECRET_NAME") + `"
+						description = "Test SSM activation with default tags"
+
+						tags = {
+							Owner = "test-team"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("test_ssm_activation.test", "tags_all.CostCenter", "finance"),
+					resource.TestCheckResourceAttr("test_ssm_activation.test", "tags_all.Owner", "test-team"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccSSMActivationResource_ReservedDefaultTag vérifie que le provider refuse un tag par défaut
+// utilisant le préfixe réservé "aws:".
+func TestAccSSMActivationResource_ReservedDefaultTag(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE_OTHER_AGAIN") + `"
+						default_tags {
+							tags = {
+								"aws:createdBy" = "terraform"
+							}
+						}
+					}
+
+					resource "test_ssm_activation" "test" {
+						iam_role = "` + getVar("ACTIVATION_ROLE_NAME") + `"
+						secret_name = "` + getVar("SECRET_NAME") + `"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`uses the reserved "aws:" prefix`),
+			},
+		},
+	})
+}
@@ -0,0 +1,142 @@
+package test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccSSMSendCommandAction_Basic teste l'invocation de l'action test_ssm_send_command.
+// L'action est déclenchée à la création d'une ressource terraform_data : l'apply réussit
+// si la commande SSM s'est terminée avec succès sur l'instance.
+func TestAccSSMSendCommandAction_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion1_14_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+						assume_role {
+							role_arn = "` + getVar("ROLE_ARN") + `"
+						}
+					}
+
+					action "test_ssm_send_command" "test" {
+						config {
+							document_name = "AWS-RunShellScript"
+							instance_ids  = ["` + getVar("INSTANCE_ID") + `"]
+
+							parameters = {
+								"commands" = "pwd"
+							}
+
+							comment = "Test SSM send command action"
+							timeout = "5m"
+						}
+					}
+
+					resource "terraform_data" "test" {
+						lifecycle {
+							action_trigger {
+								events  = [after_create]
+								actions = [action.test_ssm_send_command.test]
+							}
+						}
+					}
+				`,
+				Check: resource.TestCheckResourceAttrSet("terraform_data.test", "id"),
+			},
+		},
+	})
+}
+
+// TestAccSSMSendCommandAction_Failed teste qu'une commande en échec fait toujours échouer
+// l'action, avec la sortie de la commande dans le diagnostic.
+func TestAccSSMSendCommandAction_Failed(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion1_14_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+						assume_role {
+							role_arn = "` + getVar("ROLE_ARN") + `"
+						}
+					}
+
+					action "test_ssm_send_command" "test" {
+						config {
+							document_name = "AWS-RunShellScript"
+							instance_ids  = ["` + getVar("INSTANCE_ID") + `"]
+
+							parameters = {
+								"commands" = "pwdpwdpwd"
+							}
+						}
+					}
+
+					resource "terraform_data" "test" {
+						lifecycle {
+							action_trigger {
+								events  = [after_create]
+								actions = [action.test_ssm_send_command.test]
+							}
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?s)SSM command failed.*pwdpwdpwd`),
+			},
+		},
+	})
+}
+
+// TestAccSSMSendCommandAction_InvalidTimeout teste que l'attribut timeout de l'action est
+// validé au moment du plan, avant l'envoi de la commande.
+func TestAccSSMSendCommandAction_InvalidTimeout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion1_14_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+					}
+
+					action "test_ssm_send_command" "test" {
+						config {
+							document_name = "AWS-RunShellScript"
+							instance_ids  = ["` + getVar("INSTANCE_ID") + `"]
+							timeout       = "5 minutes"
+						}
+					}
+
+					resource "terraform_data" "test" {
+						lifecycle {
+							action_trigger {
+								events  = [after_create]
+								actions = [action.test_ssm_send_command.test]
+							}
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`"5 minutes" is not a valid timeout`),
+			},
+		},
+	})
+}
@@ -222,6 +222,102 @@ func TestAccSSMSendCommandResource_StatusFailed(t *testing.T) {
 	})
 }
 
+// TestAccSSMSendCommandResource_FailureModeError teste qu'avec failure_mode = "error", une commande
+// en échec fait échouer l'apply et que le diagnostic contient la sortie de la commande.
+func TestAccSSMSendCommandResource_FailureModeError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region  = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+						assume_role {
+							role_arn = "` + getVar("ROLE_ARN") + `"
+						}
+					}
+
+					resource "test_ssm_send_command" "test" {
+						document_name = "AWS-RunShellScript"
+						instance_ids  = ["` + getVar("INSTANCE_ID") + `"]
+						failure_mode  = "error"
+
+						parameters = {
+							"commands" = "pwdpwdpwd"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?s)SSM command failed.*pwdpwdpwd`),
+			},
+		},
+	})
+}
+
+// TestAccSSMSendCommandResource_FailureModeWarn teste qu'avec failure_mode = "warn", une commande
+// en échec ne fait pas échouer l'apply et que son statut est enregistré.
+func TestAccSSMSendCommandResource_FailureModeWarn(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region  = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+						assume_role {
+							role_arn = "` + getVar("ROLE_ARN") + `"
+						}
+					}
+
+					resource "test_ssm_send_command" "test" {
+						document_name = "AWS-RunShellScript"
+						instance_ids  = ["` + getVar("INSTANCE_ID") + `"]
+						failure_mode  = "warn"
+
+						parameters = {
+							"commands" = "pwdpwdpwd"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "failure_mode", "warn"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "status", "Failed"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccSSMSendCommandResource_InvalidFailureMode teste qu'une valeur inconnue de failure_mode
+// est refusée lors du plan.
+func TestAccSSMSendCommandResource_InvalidFailureMode(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region  = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+					}
+
+					resource "test_ssm_send_command" "test" {
+						document_name = "AWS-RunShellScript"
+						instance_ids  = ["` + getVar("INSTANCE_ID") + `"]
+						failure_mode  = "fail"
+
+						parameters = {
+							"commands" = "pwd"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`failure_mode must be one of error, warn, ignore, got 'fail'`),
+			},
+		},
+	})
+}
+
 // TestAccSSMSendCommandResource_Lifecycle teste le cycle de vie complet d'une ressource SSM Send Command.
 // Ce test vérifie les trois phases principales : Create (création initiale), Update (mise à jour avec
 // triggers modifiés), et Delete (suppression propre). Il utilise le mécanisme de triggers pour forcer
@@ -368,6 +464,109 @@ func TestAccSSMSendCommandResource_Validation(t *testing.T) {
 	})
 }
 
+// TestAccSSMSendCommandResource_InvalidRegion teste qu'une région inconnue dans l'attribut region
+// de la ressource est refusée lors du plan, avec le même message que la région du provider.
+func TestAccSSMSendCommandResource_InvalidRegion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region  = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+						assume_role {
+							role_arn = "` + getVar("ROLE_ARN") + `"
+						}
+					}
+
+					resource "test_ssm_send_command" "test" {
+						document_name = "AWS-RunShellScript"
+						instance_ids  = ["` + getVar("INSTANCE_ID") + `"]
+						region        = "eu-middle-9"
+
+						parameters = {
+							"commands" = "echo 'Test region'"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`invalid AWS Region: eu-middle-9`),
+			},
+		},
+	})
+}
+
+// TestAccSSMSendCommandResource_InvalidTimeout teste qu'une durée invalide dans le bloc timeouts
+// est refusée lors du plan.
+func TestAccSSMSendCommandResource_InvalidTimeout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region  = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+						assume_role {
+							role_arn = "` + getVar("ROLE_ARN") + `"
+						}
+					}
+
+					resource "test_ssm_send_command" "test" {
+						document_name = "AWS-RunShellScript"
+						instance_ids  = ["` + getVar("INSTANCE_ID") + `"]
+
+						parameters = {
+							"commands" = "echo 'Test timeout'"
+						}
+
+						timeouts {
+							create = "twenty minutes"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`"twenty minutes" is not a valid timeout`),
+			},
+		},
+	})
+}
+
+// TestAccSSMSendCommandResource_Timeout teste que le délai du bloc timeouts est respecté :
+// une commande qui dure plus longtemps que le délai create fait échouer l'apply, avec le dernier
+// statut lu et le nombre de lectures effectuées.
+func TestAccSSMSendCommandResource_Timeout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region  = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+						assume_role {
+							role_arn = "` + getVar("ROLE_ARN") + `"
+						}
+					}
+
+					resource "test_ssm_send_command" "test" {
+						document_name = "AWS-RunShellScript"
+						instance_ids  = ["` + getVar("INSTANCE_ID") + `"]
+
+						parameters = {
+							"commands" = "sleep 60"
+						}
+
+						timeouts {
+							create = "10s"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?s)Timeout while waiting for SSM command to complete.*polled \d+ times over 10s, last status '(Pending|InProgress)'`),
+			},
+		},
+	})
+}
+
 // TestAccSSMSendCommandResource_DefaultProfile teste l'envoi d'une commande SSM basique en utilisant
 // le profil AWS_PROFILE_OTHER (sans assume_role). Ce test configure le provider avec l'attribut profile,
 // utilise le profil AWS_PROFILE_OTHER=3098, crée une ressource SSM Send Command avec l'instance
@@ -658,3 +857,446 @@ func TestAccSSMSendCommandResource_TriggersBehavior(t *testing.T) {
 }
 
 
+
+// TestAccSSMSendCommandResource_TriggersReplace teste l'attribut triggers_replace, qui accepte une
+// valeur de n'importe quel type. Ce test vérifie qu'un changement de triggers_replace remplace la
+// ressource et renvoie la commande, alors qu'un changement d'un autre attribut (comment) est appliqué
+// sur place sans nouvelle commande.
+func TestAccSSMSendCommandResource_TriggersReplace(t *testing.T) {
+	var firstCommandId, secondCommandId string
+
+	config := func(version, comment string) string {
+		return `
+			provider "test" {
+				region = "eu-west-1"
+				profile = "` + getVar("AWS_PROFILE_OTHER") + `"
+			}
+
+			resource "test_ssm_send_command" "test" {
+				document_name = "AWS-RunShellScript"
+				instance_ids  = ["` + getVar("INSTANCE_ID") + `"]
+				comment       = "` + comment + `"
+
+				triggers_replace = {
+					version = "` + version + `"
+					files   = ["install.sh", "configure.sh"]
+				}
+
+				parameters = {
+					"commands" = "echo 'Hello from Terraform'"
+				}
+			}
+		`
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Étape 1: Create - Création initiale avec triggers_replace
+			{
+				Config: config("v1.0.0", "initial"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("test_ssm_send_command.test", "command_id"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "triggers_replace.version", "v1.0.0"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "triggers_replace.files.#", "2"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "status", "Success"),
+					func(s *terraform.State) error {
+						firstCommandId = s.RootModule().Resources["test_ssm_send_command.test"].Primary.Attributes["command_id"]
+						return nil
+					},
+				),
+			},
+			// Étape 2: Update - Changement de triggers_replace (remplace la ressource et renvoie la commande)
+			{
+				Config: config("v2.0.0", "initial"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "triggers_replace.version", "v2.0.0"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "status", "Success"),
+					func(s *terraform.State) error {
+						secondCommandId = s.RootModule().Resources["test_ssm_send_command.test"].Primary.Attributes["command_id"]
+						if firstCommandId == secondCommandId {
+							return fmt.Errorf("command_id should have changed when triggers_replace changed: %s", secondCommandId)
+						}
+						return nil
+					},
+				),
+			},
+			// Étape 3: Update - Changement du commentaire seul (mise à jour sur place, pas de nouvelle commande)
+			{
+				Config: config("v2.0.0", "updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "comment", "updated"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "status", "Success"),
+					func(s *terraform.State) error {
+						commandId := s.RootModule().Resources["test_ssm_send_command.test"].Primary.Attributes["command_id"]
+						if commandId != secondCommandId {
+							return fmt.Errorf("command_id should not have changed when only the comment changed: %s != %s", secondCommandId, commandId)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// TestAccSSMSendCommandResource_RefreshStatus teste que Read relit le statut de la commande depuis SSM.
+// Ce test crée une commande, rafraîchit l'état puis vérifie que le statut et l'identifiant de la
+// commande sont conservés et qu'aucune modification n'est planifiée après le rafraîchissement.
+func TestAccSSMSendCommandResource_RefreshStatus(t *testing.T) {
+	config := `
+		provider "test" {
+			region = "eu-west-1"
+			profile = "` + getVar("AWS_PROFILE_OTHER") + `"
+		}
+
+		resource "test_ssm_send_command" "test" {
+			document_name = "AWS-RunShellScript"
+			instance_ids  = ["` + getVar("INSTANCE_ID") + `"]
+
+			parameters = {
+				"commands" = "echo 'Hello from Terraform'"
+			}
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("test_ssm_send_command.test", "command_id"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "status", "Success"),
+				),
+			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("test_ssm_send_command.test", "id", "test_ssm_send_command.test", "command_id"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "status", "Success"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// TestAccSSMSendCommandResource_Invocations teste l'attribut calculé invocations. Ce test exécute une
+// commande qui écrit sur la sortie standard et sur la sortie d'erreur puis se termine avec un code
+// de retour non nul, et vérifie que le résultat de l'instance (statut, code de retour, sorties et
+// étapes du document) est enregistré.
+func TestAccSSMSendCommandResource_Invocations(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE_OTHER") + `"
+					}
+
+					resource "test_ssm_send_command" "test" {
+						document_name = "AWS-RunShellScript"
+						instance_ids  = ["` + getVar("INSTANCE_ID") + `"]
+						failure_mode  = "warn"
+
+						parameters = {
+							"commands" = "echo 'generated-token'; echo 'something went wrong' >&2; exit 3"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "status", "Failed"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "invocations.#", "1"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "invocations.0.instance_id", getVar("INSTANCE_ID")),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "invocations.0.status", "Failed"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "invocations.0.response_code", "3"),
+					resource.TestMatchResourceAttr("test_ssm_send_command.test", "invocations.0.standard_output", regexp.MustCompile(`generated-token`)),
+					resource.TestMatchResourceAttr("test_ssm_send_command.test", "invocations.0.standard_error", regexp.MustCompile(`something went wrong`)),
+					resource.TestCheckResourceAttrSet("test_ssm_send_command.test", "invocations.0.execution_start_date_time"),
+					resource.TestCheckResourceAttrSet("test_ssm_send_command.test", "invocations.0.execution_end_date_time"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "invocations.0.plugins.#", "1"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "invocations.0.plugins.0.name", "aws:runShellScript"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "invocations.0.plugins.0.response_code", "3"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "target_count", "1"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "success_count", "0"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "failed_count", "1"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccSSMSendCommandResource_Counts teste les compteurs calculés à partir des invocations d'une
+// commande ciblant des instances par tag EC2. Ce test vérifie que toutes les instances ciblées sont
+// comptées comme réussies et qu'une invocation est enregistrée pour chacune d'elles.
+func TestAccSSMSendCommandResource_Counts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+						assume_role {
+							role_arn = "` + getVar("ROLE_ARN") + `"
+						}
+					}
+
+					resource "test_ssm_send_command" "test" {
+						document_name = "AWS-RunShellScript"
+
+						targets {
+							key    = "tag:Name"
+							values = ["` + getVar("EC2_TAG_NAME") + `"]
+						}
+
+						parameters = {
+							"commands" = "echo 'Test counts'"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "status", "Success"),
+					resource.TestMatchResourceAttr("test_ssm_send_command.test", "target_count", regexp.MustCompile(`^[1-9][0-9]*$`)),
+					resource.TestCheckResourceAttrPair("test_ssm_send_command.test", "success_count", "test_ssm_send_command.test", "target_count"),
+					resource.TestCheckResourceAttrPair("test_ssm_send_command.test", "invocations.#", "test_ssm_send_command.test", "target_count"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "failed_count", "0"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "timed_out_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccSSMSendCommandResource_ExecutionOptions teste l'envoi d'une commande avec les options
+// de contrôle du débit et de version du document : la commande s'exécute normalement et les
+// options sont conservées dans l'état.
+func TestAccSSMSendCommandResource_ExecutionOptions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region  = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+						assume_role {
+							role_arn = "` + getVar("ROLE_ARN") + `"
+						}
+					}
+
+					resource "test_ssm_send_command" "test" {
+						document_name    = "AWS-RunShellScript"
+						document_version = "$LATEST"
+
+						targets {
+							key    = "tag:Name"
+							values = ["` + getVar("EC2_TAG_NAME") + `"]
+						}
+
+						parameters = {
+							"commands" = "pwd"
+						}
+
+						max_concurrency = "1"
+						max_errors      = "0"
+						timeout_seconds = 120
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "status", "Success"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "document_version", "$LATEST"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "max_concurrency", "1"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "max_errors", "0"),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "timeout_seconds", "120"),
+					resource.TestCheckResourceAttrPair("test_ssm_send_command.test", "success_count", "test_ssm_send_command.test", "target_count"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccSSMSendCommandResource_InvalidExecutionOptions teste que des options d'exécution
+// invalides sont refusées lors du plan, avant l'envoi de la commande.
+func TestAccSSMSendCommandResource_InvalidExecutionOptions(t *testing.T) {
+	config := func(options string) string {
+		return `
+			provider "test" {
+				region  = "eu-west-1"
+				profile = "` + getVar("AWS_PROFILE") + `"
+			}
+
+			resource "test_ssm_send_command" "test" {
+				document_name = "AWS-RunShellScript"
+				instance_ids  = ["` + getVar("INSTANCE_ID") + `"]
+
+				parameters = {
+					"commands" = "pwd"
+				}
+
+				` + options + `
+			}
+		`
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(`max_concurrency = "0"`),
+				ExpectError: regexp.MustCompile(`max_concurrency must be a positive number`),
+			},
+			{
+				Config:      config(`max_errors = "150%"`),
+				ExpectError: regexp.MustCompile(`max_errors must be a number`),
+			},
+			{
+				Config:      config(`max_errors = "010"`),
+				ExpectError: regexp.MustCompile(`max_errors must be a number`),
+			},
+			{
+				Config:      config(`timeout_seconds = 10`),
+				ExpectError: regexp.MustCompile(`timeout_seconds must be between 30 and 2592000, got 10`),
+			},
+			{
+				Config:      config(`document_version = "latest"`),
+				ExpectError: regexp.MustCompile(`document_version must be \$DEFAULT, \$LATEST or a version number`),
+			},
+			{
+				Config:      config(`document_hash = "abc"`),
+				ExpectError: regexp.MustCompile(`document_hash must be a SHA-256 hash`),
+			},
+			{
+				Config:      config(`service_role_arn = "ssm-notifications"`),
+				ExpectError: regexp.MustCompile(`Invalid IAM role ARN`),
+			},
+			{
+				Config:      config(`service_role_arn = "arn:aws:iam::123456789012:user/ssm-notifications"`),
+				ExpectError: regexp.MustCompile(`(?s)Invalid IAM role ARN.*invalid role name value`),
+			},
+			{
+				Config: config(`
+					notification_config {
+						notification_arn = "arn:aws:sns:eu-west-1:123456789012:ssm-commands"
+					}
+				`),
+				ExpectError: regexp.MustCompile(`service_role_arn must be set`),
+			},
+			{
+				Config: config(`
+					service_role_arn = "arn:aws:iam::123456789012:role/ssm-notifications"
+
+					notification_config {
+						notification_arn    = "arn:aws:sns:eu-west-1:123456789012:ssm-commands"
+						notification_events = ["Succeeded"]
+					}
+				`),
+				ExpectError: regexp.MustCompile(`(?s)Invalid notification_events.*got 'Succeeded'`),
+			},
+		},
+	})
+}
+
+// TestAccSSMSendCommandResource_OutputConfig teste l'envoi de la sortie complète de la commande
+// vers S3 et CloudWatch Logs : les URL S3 et les log streams sont exposés pour chaque invocation
+// et pour chaque étape du document.
+func TestAccSSMSendCommandResource_OutputConfig(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region  = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+						assume_role {
+							role_arn = "` + getVar("ROLE_ARN") + `"
+						}
+					}
+
+					resource "test_ssm_send_command" "test" {
+						document_name = "AWS-RunShellScript"
+						instance_ids  = ["` + getVar("INSTANCE_ID") + `"]
+
+						parameters = {
+							"commands" = "echo output"
+						}
+
+						output_s3_bucket_name = "` + getVar("OUTPUT_S3_BUCKET_NAME") + `"
+						output_s3_key_prefix  = "terraform-provider-test"
+
+						cloudwatch_output_config {
+							log_group_name = "/terraform-provider-test/ssm"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "status", "Success"),
+					resource.TestMatchResourceAttr("test_ssm_send_command.test", "invocations.0.standard_output_url", regexp.MustCompile(getVar("OUTPUT_S3_BUCKET_NAME")+`.*/terraform-provider-test/.*/stdout$`)),
+					resource.TestMatchResourceAttr("test_ssm_send_command.test", "invocations.0.plugins.0.standard_output_url", regexp.MustCompile(`/stdout$`)),
+					resource.TestCheckResourceAttr("test_ssm_send_command.test", "invocations.0.cloudwatch_log_group_name", "/terraform-provider-test/ssm"),
+					resource.TestMatchResourceAttr("test_ssm_send_command.test", "invocations.0.standard_output_log_stream", regexp.MustCompile(`/`+getVar("INSTANCE_ID")+`/aws-runShellScript/stdout$`)),
+					resource.TestMatchResourceAttr("test_ssm_send_command.test", "invocations.0.standard_error_log_stream", regexp.MustCompile(`/aws-runShellScript/stderr$`)),
+					resource.TestCheckResourceAttrPair("test_ssm_send_command.test", "invocations.0.standard_output_log_stream", "test_ssm_send_command.test", "invocations.0.plugins.0.standard_output_log_stream"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccSSMSendCommandResource_InvalidOutputConfig teste qu'une configuration de sortie
+// invalide est refusée lors du plan, avant l'envoi de la commande.
+func TestAccSSMSendCommandResource_InvalidOutputConfig(t *testing.T) {
+	config := func(options string) string {
+		return `
+			provider "test" {
+				region  = "eu-west-1"
+				profile = "` + getVar("AWS_PROFILE") + `"
+			}
+
+			resource "test_ssm_send_command" "test" {
+				document_name = "AWS-RunShellScript"
+				instance_ids  = ["` + getVar("INSTANCE_ID") + `"]
+
+				parameters = {
+					"commands" = "pwd"
+				}
+
+				` + options + `
+			}
+		`
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(`output_s3_bucket_name = "My_Bucket"`),
+				ExpectError: regexp.MustCompile(`must be a valid S3 bucket name`),
+			},
+			{
+				Config:      config(`output_s3_key_prefix = "terraform-provider-test"`),
+				ExpectError: regexp.MustCompile(`Missing output_s3_bucket_name`),
+			},
+			{
+				Config:      config(`output_s3_region = "eu-middle-9"`),
+				ExpectError: regexp.MustCompile(`invalid AWS Region: eu-middle-9`),
+			},
+			{
+				Config: config(`
+					cloudwatch_output_config {
+						log_group_name = "ssm output"
+					}
+				`),
+				ExpectError: regexp.MustCompile(`log_group_name must be between 1 and 512`),
+			},
+		},
+	})
+}
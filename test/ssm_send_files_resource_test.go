@@ -713,3 +713,50 @@ func TestAccSSMSendFilesResource_TriggersBehavior(t *testing.T) {
 		},
 	})
 }
+
+// TestAccSSMSendFilesResource_OutputConfig teste l'envoi de la sortie complète de la commande
+// vers S3 et CloudWatch Logs : les URL S3 et les log streams de chaque invocation sont exposés
+// par l'attribut invocations.
+func TestAccSSMSendFilesResource_OutputConfig(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "test" {
+						region  = "eu-west-1"
+						profile = "` + getVar("AWS_PROFILE") + `"
+						assume_role {
+							role_arn = "` + getVar("ROLE_ARN") + `"
+						}
+					}
+
+					resource "test_ssm_send_files" "test" {
+						platform          = "linux"
+						instance_ids      = ["` + getVar("INSTANCE_ID") + `"]
+						working_directory = "/tmp"
+
+						file {
+							name    = "output.txt"
+							content = "Hello from provider-test!"
+						}
+
+						output_s3_bucket_name = "` + getVar("OUTPUT_S3_BUCKET_NAME") + `"
+
+						cloudwatch_output_config {
+							log_group_name = "/terraform-provider-test/ssm"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("test_ssm_send_files.test", "status", "Success"),
+					resource.TestCheckResourceAttr("test_ssm_send_files.test", "invocations.#", "1"),
+					resource.TestCheckResourceAttr("test_ssm_send_files.test", "invocations.0.instance_id", getVar("INSTANCE_ID")),
+					resource.TestCheckResourceAttrSet("test_ssm_send_files.test", "invocations.0.standard_output_url"),
+					resource.TestCheckResourceAttr("test_ssm_send_files.test", "invocations.0.cloudwatch_log_group_name", "/terraform-provider-test/ssm"),
+					resource.TestCheckResourceAttrSet("test_ssm_send_files.test", "invocations.0.standard_output_log_stream"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,95 @@
+package test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccTimestampFunction_Basic teste la fonction provider::test::timestamp avec
+// plantimestamp(), sans fuseau horaire ni décalage : le résultat doit être au format
+// yyyy-mm-ddThh:mm:ss.
+func TestAccTimestampFunction_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "now" {
+  value = provider::test::timestamp(plantimestamp(), null, null)
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchOutput("now", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}$`)),
+				),
+			},
+		},
+	})
+}
+
+// TestAccTimestampFunction_WithTimeZoneAndOffset teste la fonction avec une heure de référence
+// fixe, un fuseau horaire et un décalage exprimé en jours et en heures : la fonction étant pure,
+// le résultat est connu à l'avance.
+func TestAccTimestampFunction_WithTimeZoneAndOffset(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "later" {
+  value = provider::test::timestamp("2024-01-15T10:00:00Z", "Europe/Paris", "-1d2h30m")
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					// 10:00 UTC correspond à 11:00 à Paris en hiver, moins 1 jour, 2 heures et 30 minutes
+					resource.TestCheckOutput("later", "2024-01-14T08:30:00"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccTimestampFunction_InvalidArguments teste les erreurs retournées pour une heure de
+// référence qui n'est pas au format RFC 3339, un fuseau horaire inconnu et un décalage invalide.
+func TestAccTimestampFunction_InvalidArguments(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "invalid" {
+  value = provider::test::timestamp("2024-01-15 10:00:00", null, null)
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid timestamp: "2024-01-15 10:00:00" is not in RFC 3339 format`),
+			},
+			{
+				Config: `
+output "invalid" {
+  value = provider::test::timestamp("2024-01-15T10:00:00Z", "Invalid/Zone", null)
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to load timezone 'Invalid/Zone'`),
+			},
+			{
+				Config: `
+output "invalid" {
+  value = provider::test::timestamp("2024-01-15T10:00:00Z", null, "5")
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid offset: "5" is not a valid duration`),
+			},
+		},
+	})
+}
@@ -3,6 +3,7 @@ package test
 import (
 	"fmt"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/jd-ucpa/terraform-provider-test/internal"
@@ -20,6 +21,10 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"test": providerserver.NewProtocol6WithError(internal.Provider()),
 }
 
+// tfversion1_14_0 est la première version de Terraform qui prend en charge les actions.
+// terraform-plugin-testing ne fournit pas encore de constante pour cette version.
+var tfversion1_14_0 = version.Must(version.NewVersion("1.14.0"))
+
 // getVar récupère une valeur de la configuration de test
 func getVar(key string) string {
 	if testConfig == nil {